@@ -0,0 +1,152 @@
+package rebound
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+)
+
+// MeshLoader loads the mesh identified by source, such as "helmet.gltf#0", into the GPU
+type MeshLoader func(source string) (*Mesh, error)
+
+var (
+	meshLoaders = make(map[string]MeshLoader)
+	meshCache   = make(map[string]*Mesh)
+	// memoryMeshes holds the sources of meshes created in code, which are only valid within this process
+	memoryMeshes = make(map[*Mesh]string)
+	memoryCount  int
+	// meshRefs counts the users of every mesh, shared by all render systems
+	meshRefs    = make(map[*Mesh]int)
+	meshUnloads []func(m *Mesh)
+	assetsMutex sync.Mutex
+)
+
+// RegisterMeshLoader registers the loader for mesh sources whose file has the given extension, such as ".gltf".
+// Importers register their loaders, which allows scenes to restore meshes from their [Mesh.Source]
+func RegisterMeshLoader(ext string, loader MeshLoader) {
+	assetsMutex.Lock()
+	meshLoaders[strings.ToLower(ext)] = loader
+	assetsMutex.Unlock()
+}
+
+// OnMeshUnload registers a function that is called after [UnloadMesh] removed a mesh.
+// Importers use it to forget data they cache for the mesh, such as its parsed file
+func OnMeshUnload(f func(m *Mesh)) {
+	assetsMutex.Lock()
+	meshUnloads = append(meshUnloads, f)
+	assetsMutex.Unlock()
+}
+
+// RetainMesh adds a reference to the mesh, which keeps it loaded while a [RenderSystem] releases meshes.
+// Every [RenderSystem] retains the meshes of its entities, so a mesh shared by multiple systems is only unloaded once none of them use it
+func RetainMesh(m *Mesh) {
+	assetsMutex.Lock()
+	meshRefs[m]++
+	assetsMutex.Unlock()
+}
+
+// ReleaseMesh removes a reference added by [RetainMesh] and returns whether the mesh is no longer referenced
+func ReleaseMesh(m *Mesh) bool {
+	assetsMutex.Lock()
+	defer assetsMutex.Unlock()
+
+	meshRefs[m]--
+	if meshRefs[m] > 0 {
+		return false
+	}
+	delete(meshRefs, m)
+	return true
+}
+
+// meshRetained returns whether the mesh is referenced, see RetainMesh
+func meshRetained(m *Mesh) bool {
+	assetsMutex.Lock()
+	defer assetsMutex.Unlock()
+	return meshRefs[m] > 0
+}
+
+// LoadMeshSource returns the mesh identified by source, loading it using the registered [MeshLoader] for its extension.
+// Meshes are loaded once and shared by every caller until they are unloaded
+func LoadMeshSource(source string) (*Mesh, error) {
+	assetsMutex.Lock()
+	if m, exists := meshCache[source]; exists {
+		assetsMutex.Unlock()
+		return m, nil
+	}
+
+	file := source
+	if i := strings.LastIndex(source, "#"); i >= 0 {
+		file = source[:i]
+	}
+	loader, exists := meshLoaders[strings.ToLower(path.Ext(file))]
+	assetsMutex.Unlock()
+	if !exists {
+		return nil, fmt.Errorf("rebound: no mesh loader registered for %s", source)
+	}
+
+	// the loader runs without the lock, as it loads the textures of the mesh
+	m, err := loader(source)
+	if err != nil {
+		return nil, err
+	}
+	m.Source = source
+
+	assetsMutex.Lock()
+	if loaded, exists := meshCache[source]; exists {
+		// the mesh was loaded by another caller in the meantime
+		assetsMutex.Unlock()
+		UnloadMesh(m)
+		return loaded, nil
+	}
+	meshCache[source] = m
+	assetsMutex.Unlock()
+	return m, nil
+}
+
+// memorySource returns a source that references the mesh in memory, for a mesh that was not loaded from an asset.
+// The mesh is added to the cache of loaded meshes, so [LoadMeshSource] returns it until it is unloaded
+func memorySource(m *Mesh) string {
+	assetsMutex.Lock()
+	defer assetsMutex.Unlock()
+
+	if source, exists := memoryMeshes[m]; exists {
+		return source
+	}
+	memoryCount++
+	source := fmt.Sprintf("memory#%d", memoryCount)
+	memoryMeshes[m] = source
+	meshCache[source] = m
+	return source
+}
+
+// forgetMesh removes the mesh from the cache of loaded meshes and notifies the functions registered by OnMeshUnload
+func forgetMesh(m *Mesh) {
+	assetsMutex.Lock()
+	if meshCache[m.Source] == m {
+		delete(meshCache, m.Source)
+	}
+	if source, exists := memoryMeshes[m]; exists {
+		delete(meshCache, source)
+		delete(memoryMeshes, m)
+	}
+	unloads := meshUnloads
+	assetsMutex.Unlock()
+
+	for _, f := range unloads {
+		f(m)
+	}
+}
+
+// TexturePath returns the file a texture was loaded from using [LoadTexture]
+func TexturePath(id uint32) (string, bool) {
+	assetsMutex.Lock()
+	defer assetsMutex.Unlock()
+
+	for file, texture := range textures {
+		if texture == id {
+			return file, true
+		}
+	}
+	return "", false
+}
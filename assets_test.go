@@ -0,0 +1,81 @@
+package rebound
+
+import (
+	"image/color"
+	"sync/atomic"
+	"testing"
+
+	"github.com/luukdegram/rebound/ecs"
+)
+
+func TestOnMeshUnload(t *testing.T) {
+	rs, _ := newSoftwareRenderer(t, 8, 8)
+	rs.ReleaseMeshes = true
+	quad := newQuad(newSolidTexture(color.RGBA{255, 0, 0, 255}))
+
+	var unloaded atomic.Bool
+	OnMeshUnload(func(m *Mesh) {
+		if m == quad {
+			unloaded.Store(true)
+		}
+	})
+
+	e := ecs.NewEntity(&RenderComponent{Mesh: quad, Scale: [3]float32{1, 1, 1}})
+	rs.AddEntities(e)
+	rs.RemoveEntity(e)
+	if unloaded.Load() {
+		t.Errorf("OnMeshUnload failed. Expected the mesh to be unloaded by the next update")
+	}
+	rs.Update(0)
+	if !unloaded.Load() || quad.ID != 0 {
+		t.Errorf("OnMeshUnload failed. Expected the released mesh to be reported as unloaded")
+	}
+}
+
+func TestSharedMeshRelease(t *testing.T) {
+	main, _ := newSoftwareRenderer(t, 8, 8)
+	minimap, err := NewRenderSystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	minimap.NewCamera(8, 8)
+	main.ReleaseMeshes, minimap.ReleaseMeshes = true, true
+	quad := newQuad(newSolidTexture(color.RGBA{255, 0, 0, 255}))
+
+	e1 := ecs.NewEntity(&RenderComponent{Mesh: quad, Scale: [3]float32{1, 1, 1}})
+	e2 := ecs.NewEntity(&RenderComponent{Mesh: quad, Scale: [3]float32{1, 1, 1}})
+	main.AddEntities(e1)
+	minimap.AddEntities(e2)
+
+	main.RemoveEntity(e1)
+	main.Update(0)
+	if quad.ID == 0 {
+		t.Fatalf("RemoveEntity failed. Expected the mesh to stay loaded while another system uses it")
+	}
+
+	minimap.RemoveEntity(e2)
+	minimap.Update(0)
+	if quad.ID != 0 {
+		t.Errorf("RemoveEntity failed. Expected the mesh to be unloaded once no system uses it")
+	}
+}
+
+func TestRetainMesh(t *testing.T) {
+	rs, _ := newSoftwareRenderer(t, 8, 8)
+	rs.ReleaseMeshes = true
+	quad := newQuad(newSolidTexture(color.RGBA{255, 0, 0, 255}))
+
+	// a prefab retains its meshes while it is cached
+	RetainMesh(quad)
+	e := ecs.NewEntity(&RenderComponent{Mesh: quad, Scale: [3]float32{1, 1, 1}})
+	rs.AddEntities(e)
+	rs.RemoveEntity(e)
+	rs.Update(0)
+	if quad.ID == 0 {
+		t.Fatalf("RetainMesh failed. Expected the retained mesh to stay loaded")
+	}
+
+	if !ReleaseMesh(quad) {
+		t.Errorf("ReleaseMesh failed. Expected the mesh to be unused")
+	}
+}
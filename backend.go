@@ -0,0 +1,134 @@
+package rebound
+
+import (
+	"image"
+	"sync"
+
+	"github.com/luukdegram/rebound/internal/thread"
+)
+
+// Backend executes the rendering of Rebound, such as creating buffers, textures and shader programs and drawing meshes.
+// Its methods are called on the main thread, see [RenderSystem].
+// The [OpenGLBackend] is used by default, the [SoftwareBackend] renders into an image without a window or GPU
+type Backend interface {
+	// CreateMesh stores the indices and attributes of a mesh, returning the id of the mesh and of each of its buffers.
+	// A mesh without indices is drawn in the order of its vertices
+	CreateMesh(indices []uint32, attributes []Attribute) (id uint32, buffers []uint32)
+	// DeleteMesh removes a mesh and its buffers
+	DeleteMesh(id uint32, buffers []uint32)
+	// CreateTexture stores an image as texture, which repeats and is filtered linearly
+	CreateTexture(img *image.RGBA) uint32
+	// CreateCubeMap stores 6 images as the faces of a cube map, ordered +X, -X, +Y, -Y, +Z, -Z
+	CreateCubeMap(faces [6]*image.RGBA) uint32
+	// DeleteTexture removes a texture or cube map
+	DeleteTexture(id uint32)
+	// CreateProgram compiles the vertex and fragment shader into a shader program
+	CreateProgram(vertexShader, fragmentShader string) (uint32, error)
+	// DeleteProgram removes a shader program
+	DeleteProgram(id uint32)
+	// UseProgram sets the program used by the next draws, 0 stops using a program
+	UseProgram(id uint32)
+	// SetFloat sets a float uniform of the program
+	SetFloat(program uint32, name string, value float32)
+	// SetInt sets an integer uniform of the program
+	SetInt(program uint32, name string, value int32)
+	// SetVec3 sets a vector uniform of the program
+	SetVec3(program uint32, name string, value [3]float32)
+	// SetVec4 sets a vector uniform with 4 elements of the program
+	SetVec4(program uint32, name string, value [4]float32)
+	// SetMat4 sets a matrix uniform of the program
+	SetMat4(program uint32, name string, value [16]float32)
+	// BindTexture binds a texture or cube map to the texture unit
+	BindTexture(unit int, id uint32)
+	// SetState changes the state used by the next draws
+	SetState(state RenderState)
+	// Clear clears the colour and depth of the frame
+	Clear(c Colour)
+	// Draw draws count vertices of the mesh as triangles using the current program
+	Draw(mesh uint32, count int)
+	// CreateRenderTarget creates a framebuffer with a colour texture of the given format and, unless [DepthNone], a depth buffer.
+	// It returns the id of the framebuffer and of its texture, which can be bound like any other texture
+	CreateRenderTarget(width, height int, format TextureFormat, depth DepthFormat) (id uint32, texture uint32, err error)
+	// DeleteRenderTarget removes a framebuffer and its texture
+	DeleteRenderTarget(id uint32)
+	// BindRenderTarget sets the framebuffer the next clears and draws render into, 0 renders into the window
+	BindRenderTarget(id uint32)
+	// ReadPixels reads back the colour of a framebuffer, 0 being the window.
+	// Like OpenGL, the first row of the image is the bottom of the frame
+	ReadPixels(id uint32) (*image.RGBA, error)
+}
+
+// DepthFunc compares the depth of a fragment with the depth of the frame
+type DepthFunc int
+
+const (
+	// DepthLess passes fragments that are closer than the current depth
+	DepthLess DepthFunc = iota
+	// DepthLessEqual passes fragments that are closer than or as close as the current depth
+	DepthLessEqual
+)
+
+// TextureFormat is the format of the colour texture of a [RenderTarget]
+type TextureFormat int
+
+const (
+	// FormatRGBA8 stores every channel as a byte
+	FormatRGBA8 TextureFormat = iota
+	// FormatRGBA16F stores every channel as a half precision float, i.e. for HDR
+	FormatRGBA16F
+	// FormatRGBA32F stores every channel as a float
+	FormatRGBA32F
+)
+
+// DepthFormat is the format of the depth buffer of a [RenderTarget]
+type DepthFormat int
+
+const (
+	// DepthNone creates no depth buffer, which disables depth testing while rendering into the target
+	DepthNone DepthFormat = iota
+	// Depth24 stores the depth as a 24 bit integer
+	Depth24
+	// Depth32F stores the depth as a float
+	Depth32F
+)
+
+// RenderState holds the state that changes how meshes are drawn
+type RenderState struct {
+	CullFace  bool
+	DepthTest bool
+	DepthFunc DepthFunc
+	Wireframe bool
+}
+
+var (
+	backend      Backend
+	backendMutex sync.RWMutex
+)
+
+// SetBackend sets the [Backend] used to render, it must be set before any mesh, texture or shader is loaded.
+// A [SoftwareBackend] renders without a window, so while it is set the calls for the main thread run on the calling goroutine
+func SetBackend(b Backend) {
+	backendMutex.Lock()
+	backend = b
+	backendMutex.Unlock()
+
+	_, software := b.(*SoftwareBackend)
+	thread.SetInline(software)
+}
+
+// CurrentBackend returns the [Backend] used to render, the [OpenGLBackend] unless another one was set
+func CurrentBackend() Backend {
+	backendMutex.RLock()
+	b := backend
+	backendMutex.RUnlock()
+	if b != nil {
+		return b
+	}
+
+	backendMutex.Lock()
+	defer backendMutex.Unlock()
+	if backend == nil {
+		backend = NewOpenGLBackend()
+	}
+	return backend
+}
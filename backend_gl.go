@@ -0,0 +1,437 @@
+package rebound
+
+import (
+	"fmt"
+	"image"
+	"strings"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// OpenGLBackend renders using OpenGL 4.1, which requires the context created by the window
+type OpenGLBackend struct {
+	meshes       map[uint32]glMesh
+	targets      map[uint32]uint32
+	programs     map[uint32]map[string]int32
+	framebuffers map[uint32]glFramebuffer
+	framebuffer  uint32
+	viewport     [4]int32
+}
+
+// glMesh holds the attribute locations of a vao and whether it is drawn using indices
+type glMesh struct {
+	attributes []uint32
+	indexed    bool
+}
+
+// glFramebuffer holds the attachments and size of a framebuffer
+type glFramebuffer struct {
+	texture, depth uint32
+	width, height  int32
+}
+
+// NewOpenGLBackend returns a new OpenGL backend
+func NewOpenGLBackend() *OpenGLBackend {
+	return &OpenGLBackend{
+		meshes:       make(map[uint32]glMesh),
+		targets:      make(map[uint32]uint32),
+		programs:     make(map[uint32]map[string]int32),
+		framebuffers: make(map[uint32]glFramebuffer),
+	}
+}
+
+// CreateMesh creates a new vao and stores the mesh data inside its buffers
+func (b *OpenGLBackend) CreateMesh(indices []uint32, attributes []Attribute) (uint32, []uint32) {
+	var vao uint32
+	gl.GenVertexArrays(1, &vao)
+	gl.BindVertexArray(vao)
+
+	var buffers []uint32
+	if len(indices) > 0 {
+		buffers = append(buffers, bindIndicesBuffer(indices))
+	}
+	mesh := glMesh{indexed: len(indices) > 0}
+	for _, attribute := range attributes {
+		buffers = append(buffers, storeDataInAttributeList(int(attribute.Type), attribute.Size, attribute.Data))
+		mesh.attributes = append(mesh.attributes, uint32(attribute.Type))
+	}
+	gl.BindVertexArray(0)
+
+	b.meshes[vao] = mesh
+	return vao, buffers
+}
+
+// DeleteMesh removes the vao and buffers of the mesh from the GPU
+func (b *OpenGLBackend) DeleteMesh(id uint32, buffers []uint32) {
+	gl.DeleteVertexArrays(1, &id)
+	if len(buffers) > 0 {
+		gl.DeleteBuffers(int32(len(buffers)), &buffers[0])
+	}
+	delete(b.meshes, id)
+}
+
+// CreateTexture loads the image into a texture with mipmaps
+func (b *OpenGLBackend) CreateTexture(img *image.RGBA) uint32 {
+	var texture uint32
+	gl.GenTextures(1, &texture)
+	gl.BindTexture(gl.TEXTURE_2D, texture)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.REPEAT)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.REPEAT)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexImage2D(
+		gl.TEXTURE_2D,
+		0,
+		gl.RGBA,
+		int32(img.Rect.Size().X),
+		int32(img.Rect.Size().Y),
+		0,
+		gl.RGBA,
+		gl.UNSIGNED_BYTE,
+		gl.Ptr(img.Pix))
+	gl.GenerateMipmap(gl.TEXTURE_2D)
+
+	b.targets[texture] = gl.TEXTURE_2D
+	return texture
+}
+
+// CreateCubeMap loads the images into the faces of a cube map texture
+func (b *OpenGLBackend) CreateCubeMap(faces [6]*image.RGBA) uint32 {
+	var texture uint32
+	gl.GenTextures(1, &texture)
+	gl.BindTexture(gl.TEXTURE_CUBE_MAP, texture)
+	for index, face := range faces {
+		gl.TexImage2D(gl.TEXTURE_CUBE_MAP_POSITIVE_X+uint32(index),
+			0,
+			gl.RGBA,
+			int32(face.Rect.Size().X),
+			int32(face.Rect.Size().Y),
+			0,
+			gl.RGBA,
+			gl.UNSIGNED_BYTE,
+			gl.Ptr(face.Pix))
+	}
+
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_CUBE_MAP, gl.TEXTURE_WRAP_R, gl.CLAMP_TO_EDGE)
+
+	b.targets[texture] = gl.TEXTURE_CUBE_MAP
+	return texture
+}
+
+// DeleteTexture removes the texture from the GPU
+func (b *OpenGLBackend) DeleteTexture(id uint32) {
+	gl.DeleteTextures(1, &id)
+	delete(b.targets, id)
+}
+
+// CreateProgram compiles and links the shaders into a program
+func (b *OpenGLBackend) CreateProgram(vertexShader, fragmentShader string) (uint32, error) {
+	vID, err := compileShader(vertexShader, gl.VERTEX_SHADER)
+	if err != nil {
+		return 0, err
+	}
+	defer gl.DeleteShader(vID)
+
+	fID, err := compileShader(fragmentShader, gl.FRAGMENT_SHADER)
+	if err != nil {
+		return 0, err
+	}
+	defer gl.DeleteShader(fID)
+
+	id := gl.CreateProgram()
+	gl.AttachShader(id, vID)
+	gl.AttachShader(id, fID)
+
+	gl.LinkProgram(id)
+	gl.ValidateProgram(id)
+
+	gl.DetachShader(id, vID)
+	gl.DetachShader(id, fID)
+
+	b.programs[id] = make(map[string]int32)
+	return id, nil
+}
+
+// DeleteProgram removes the program from the GPU
+func (b *OpenGLBackend) DeleteProgram(id uint32) {
+	gl.DeleteProgram(id)
+	delete(b.programs, id)
+}
+
+// UseProgram starts the shader program
+func (b *OpenGLBackend) UseProgram(id uint32) {
+	gl.UseProgram(id)
+}
+
+// UniformLocation returns the location of the uniform within the program, which is cached after the first lookup
+func (b *OpenGLBackend) UniformLocation(program uint32, name string) int32 {
+	locations, exists := b.programs[program]
+	if !exists {
+		return gl.GetUniformLocation(program, gl.Str(name+"\x00"))
+	}
+	loc, exists := locations[name]
+	if !exists {
+		loc = gl.GetUniformLocation(program, gl.Str(name+"\x00"))
+		locations[name] = loc
+	}
+	return loc
+}
+
+// SetFloat loads a uniform float into the program
+func (b *OpenGLBackend) SetFloat(program uint32, name string, value float32) {
+	gl.ProgramUniform1f(program, b.UniformLocation(program, name), value)
+}
+
+// SetInt loads a uniform integer into the program
+func (b *OpenGLBackend) SetInt(program uint32, name string, value int32) {
+	gl.ProgramUniform1i(program, b.UniformLocation(program, name), value)
+}
+
+// SetVec3 loads a uniform vector into the program
+func (b *OpenGLBackend) SetVec3(program uint32, name string, value [3]float32) {
+	gl.ProgramUniform3f(program, b.UniformLocation(program, name), value[0], value[1], value[2])
+}
+
+// SetVec4 loads a uniform vector with 4 elements into the program
+func (b *OpenGLBackend) SetVec4(program uint32, name string, value [4]float32) {
+	gl.ProgramUniform4f(program, b.UniformLocation(program, name), value[0], value[1], value[2], value[3])
+}
+
+// SetMat4 loads a uniform matrix into the program
+func (b *OpenGLBackend) SetMat4(program uint32, name string, value [16]float32) {
+	gl.ProgramUniformMatrix4fv(program, b.UniformLocation(program, name), 1, false, &value[0])
+}
+
+// BindTexture binds the texture to the texture unit
+func (b *OpenGLBackend) BindTexture(unit int, id uint32) {
+	target, exists := b.targets[id]
+	if !exists {
+		target = gl.TEXTURE_2D
+	}
+	gl.ActiveTexture(gl.TEXTURE0 + uint32(unit))
+	gl.BindTexture(target, id)
+}
+
+// SetState enables or disables face culling, depth testing and wireframe rendering
+func (b *OpenGLBackend) SetState(state RenderState) {
+	if state.CullFace {
+		gl.Enable(gl.CULL_FACE)
+		gl.CullFace(gl.BACK)
+	} else {
+		gl.Disable(gl.CULL_FACE)
+	}
+
+	if state.DepthTest {
+		gl.Enable(gl.DEPTH_TEST)
+	} else {
+		gl.Disable(gl.DEPTH_TEST)
+	}
+	if state.DepthFunc == DepthLessEqual {
+		gl.DepthFunc(gl.LEQUAL)
+	} else {
+		gl.DepthFunc(gl.LESS)
+	}
+
+	if state.Wireframe {
+		gl.PolygonMode(gl.FRONT_AND_BACK, gl.LINE)
+	} else {
+		gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
+	}
+}
+
+// Clear clears the screen for the next draw
+func (b *OpenGLBackend) Clear(c Colour) {
+	gl.ClearColor(c.R, c.G, c.B, c.A)
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+}
+
+// Draw binds the vao of the mesh and draws its triangles
+func (b *OpenGLBackend) Draw(mesh uint32, count int) {
+	m := b.meshes[mesh]
+	gl.BindVertexArray(mesh)
+
+	// Enable all the vertex attributes (position, texcoords, colors, normals, etc)
+	for _, a := range m.attributes {
+		gl.EnableVertexAttribArray(a)
+	}
+
+	if m.indexed {
+		gl.DrawElements(gl.TRIANGLES, int32(count), gl.UNSIGNED_INT, gl.Ptr(nil))
+	} else {
+		gl.DrawArrays(gl.TRIANGLES, 0, int32(count))
+	}
+
+	// Cleanup, disable attributes and unbind vao
+	for _, a := range m.attributes {
+		gl.DisableVertexAttribArray(a)
+	}
+	gl.BindVertexArray(0)
+}
+
+// glFormats holds the internal format and data type of the texture formats
+var glFormats = map[TextureFormat][2]uint32{
+	FormatRGBA8:   {gl.RGBA8, gl.UNSIGNED_BYTE},
+	FormatRGBA16F: {gl.RGBA16F, gl.HALF_FLOAT},
+	FormatRGBA32F: {gl.RGBA32F, gl.FLOAT},
+}
+
+// CreateRenderTarget creates a framebuffer with a colour texture and a depth renderbuffer as attachments
+func (b *OpenGLBackend) CreateRenderTarget(width, height int, format TextureFormat, depth DepthFormat) (uint32, uint32, error) {
+	f, exists := glFormats[format]
+	if !exists {
+		return 0, 0, fmt.Errorf("rebound: unknown texture format %d", format)
+	}
+	fb := glFramebuffer{width: int32(width), height: int32(height)}
+
+	var id uint32
+	gl.GenFramebuffers(1, &id)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, id)
+	defer gl.BindFramebuffer(gl.FRAMEBUFFER, b.framebuffer)
+
+	gl.GenTextures(1, &fb.texture)
+	gl.BindTexture(gl.TEXTURE_2D, fb.texture)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, int32(f[0]), fb.width, fb.height, 0, gl.RGBA, f[1], nil)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, fb.texture, 0)
+
+	if depth != DepthNone {
+		internal := uint32(gl.DEPTH_COMPONENT24)
+		if depth == Depth32F {
+			internal = gl.DEPTH_COMPONENT32F
+		}
+		gl.GenRenderbuffers(1, &fb.depth)
+		gl.BindRenderbuffer(gl.RENDERBUFFER, fb.depth)
+		gl.RenderbufferStorage(gl.RENDERBUFFER, internal, fb.width, fb.height)
+		gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.RENDERBUFFER, fb.depth)
+		gl.BindRenderbuffer(gl.RENDERBUFFER, 0)
+	}
+
+	if status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER); status != gl.FRAMEBUFFER_COMPLETE {
+		b.deleteFramebuffer(id, fb)
+		return 0, 0, fmt.Errorf("rebound: incomplete framebuffer, status 0x%x", status)
+	}
+
+	b.framebuffers[id] = fb
+	b.targets[fb.texture] = gl.TEXTURE_2D
+	return id, fb.texture, nil
+}
+
+// DeleteRenderTarget removes the framebuffer and its attachments from the GPU
+func (b *OpenGLBackend) DeleteRenderTarget(id uint32) {
+	fb, exists := b.framebuffers[id]
+	if !exists {
+		return
+	}
+	if b.framebuffer == id {
+		b.BindRenderTarget(0)
+	}
+	b.deleteFramebuffer(id, fb)
+	delete(b.framebuffers, id)
+	delete(b.targets, fb.texture)
+}
+
+func (b *OpenGLBackend) deleteFramebuffer(id uint32, fb glFramebuffer) {
+	gl.DeleteFramebuffers(1, &id)
+	gl.DeleteTextures(1, &fb.texture)
+	if fb.depth != 0 {
+		gl.DeleteRenderbuffers(1, &fb.depth)
+	}
+}
+
+// BindRenderTarget binds the framebuffer and sets the viewport to its size.
+// The viewport of the window is restored when binding 0
+func (b *OpenGLBackend) BindRenderTarget(id uint32) {
+	if id == b.framebuffer {
+		return
+	}
+	fb, exists := b.framebuffers[id]
+	if id != 0 && !exists {
+		return
+	}
+
+	if b.framebuffer == 0 {
+		gl.GetIntegerv(gl.VIEWPORT, &b.viewport[0])
+	}
+	gl.BindFramebuffer(gl.FRAMEBUFFER, id)
+	if id == 0 {
+		gl.Viewport(b.viewport[0], b.viewport[1], b.viewport[2], b.viewport[3])
+	} else {
+		gl.Viewport(0, 0, fb.width, fb.height)
+	}
+	b.framebuffer = id
+}
+
+// ReadPixels reads the colour of the framebuffer, or of the back buffer of the window when id is 0
+func (b *OpenGLBackend) ReadPixels(id uint32) (*image.RGBA, error) {
+	var width, height int32
+	if id == 0 {
+		viewport := b.viewport
+		if b.framebuffer == 0 {
+			gl.GetIntegerv(gl.VIEWPORT, &viewport[0])
+		}
+		width, height = viewport[2], viewport[3]
+	} else {
+		fb, exists := b.framebuffers[id]
+		if !exists {
+			return nil, fmt.Errorf("rebound: unknown render target %d", id)
+		}
+		width, height = fb.width, fb.height
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, id)
+	gl.PixelStorei(gl.PACK_ALIGNMENT, 1)
+	gl.ReadPixels(0, 0, width, height, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(img.Pix))
+	gl.BindFramebuffer(gl.READ_FRAMEBUFFER, b.framebuffer)
+	return img, nil
+}
+
+func storeDataInAttributeList(index int, coordinateSize int, data []float32) uint32 {
+	var vbo uint32
+	gl.GenBuffers(1, &vbo)
+	gl.BindBuffer(gl.ARRAY_BUFFER, vbo)
+	gl.BufferData(gl.ARRAY_BUFFER, 4*len(data), gl.Ptr(data), gl.STATIC_DRAW)
+	gl.VertexAttribPointer(uint32(index), int32(coordinateSize), gl.FLOAT, false, 0, nil)
+	return vbo
+}
+
+func bindIndicesBuffer(indices []uint32) uint32 {
+	var ebo uint32
+	gl.GenBuffers(1, &ebo)
+	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, ebo)
+	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, 4*len(indices), gl.Ptr(indices), gl.STATIC_DRAW)
+	return ebo
+}
+
+func compileShader(source string, shaderType uint32) (uint32, error) {
+	if !strings.HasSuffix(source, "\x00") {
+		source += "\x00"
+	}
+	shader := gl.CreateShader(shaderType)
+
+	csources, free := gl.Strs(source)
+	gl.ShaderSource(shader, 1, csources, nil)
+	free()
+	gl.CompileShader(shader)
+
+	var status int32
+	gl.GetShaderiv(shader, gl.COMPILE_STATUS, &status)
+	if status == gl.FALSE {
+		var logLength int32
+		gl.GetShaderiv(shader, gl.INFO_LOG_LENGTH, &logLength)
+
+		log := strings.Repeat("\x00", int(logLength+1))
+		gl.GetShaderInfoLog(shader, logLength, nil, gl.Str(log))
+		gl.DeleteShader(shader)
+
+		return 0, fmt.Errorf("failed to compile %v: %v", source, log)
+	}
+	return shader, nil
+}
@@ -0,0 +1,523 @@
+package rebound
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"math"
+	"strings"
+	"sync"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// ErrUnsupportedShader is returned by the [SoftwareBackend] when a program is created from shaders it cannot emulate
+var ErrUnsupportedShader = errors.New("rebound: unsupported shader")
+
+// SoftwareBackend renders into an image using the CPU, which requires no window or GPU, i.e. to test rendering.
+// Shaders cannot be compiled, instead the built-in shaders, such as the [BasicShader], are emulated.
+// Creating a program from any other shader returns [ErrUnsupportedShader]
+type SoftwareBackend struct {
+	m        sync.Mutex
+	screen   *softwareTarget
+	frame    *softwareTarget
+	nextID   uint32
+	meshes   map[uint32]*softwareMesh
+	textures map[uint32][]*image.RGBA
+	programs map[uint32]*softwareProgram
+	targets  map[uint32]*softwareTarget
+	program  *softwareProgram
+	units    map[int]uint32
+	state    RenderState
+}
+
+// softwareTarget is an image and its depth that is rendered into.
+// The rows of a render target are stored bottom up, like the texture of a framebuffer in OpenGL
+type softwareTarget struct {
+	colour  *image.RGBA
+	depth   []float32
+	texture uint32
+	flip    bool
+}
+
+// softwareMesh holds the data of a mesh created by the SoftwareBackend
+type softwareMesh struct {
+	indices    []uint32
+	attributes map[AttributeType]Attribute
+	vertices   int
+}
+
+// softwareProgram is a program created by the SoftwareBackend, holding the emulated shader and its uniforms
+type softwareProgram struct {
+	shader   softwareShader
+	uniforms uniforms
+}
+
+// NewSoftwareBackend returns a SoftwareBackend that renders into an image of the given size
+func NewSoftwareBackend(width, height int) *SoftwareBackend {
+	b := &SoftwareBackend{
+		meshes:   make(map[uint32]*softwareMesh),
+		textures: make(map[uint32][]*image.RGBA),
+		programs: make(map[uint32]*softwareProgram),
+		targets:  make(map[uint32]*softwareTarget),
+		units:    make(map[int]uint32),
+	}
+	b.Resize(width, height)
+	return b
+}
+
+// newSoftwareTarget returns a cleared target of the given size, without depth when depth is false
+func newSoftwareTarget(width, height int, depth bool) *softwareTarget {
+	t := &softwareTarget{colour: image.NewRGBA(image.Rect(0, 0, width, height))}
+	if depth {
+		t.depth = make([]float32, width*height)
+		for i := range t.depth {
+			t.depth[i] = 1
+		}
+	}
+	return t
+}
+
+// Resize changes the size of the image that is rendered into, clearing its content
+func (b *SoftwareBackend) Resize(width, height int) {
+	b.m.Lock()
+	screen := newSoftwareTarget(width, height, true)
+	if b.frame == b.screen {
+		b.frame = screen
+	}
+	b.screen = screen
+	b.m.Unlock()
+}
+
+// Image returns a copy of the rendered image
+func (b *SoftwareBackend) Image() *image.RGBA {
+	b.m.Lock()
+	defer b.m.Unlock()
+	img := image.NewRGBA(b.screen.colour.Rect)
+	copy(img.Pix, b.screen.colour.Pix)
+	return img
+}
+
+// id returns a new id for a mesh, texture or program, the caller must hold the lock of the backend
+func (b *SoftwareBackend) id() uint32 {
+	b.nextID++
+	return b.nextID
+}
+
+// CreateMesh stores a copy of the indices and attributes
+func (b *SoftwareBackend) CreateMesh(indices []uint32, attributes []Attribute) (uint32, []uint32) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	m := &softwareMesh{
+		indices:    append([]uint32(nil), indices...),
+		attributes: make(map[AttributeType]Attribute, len(attributes)),
+	}
+	for _, a := range attributes {
+		a.Data = append([]float32(nil), a.Data...)
+		m.attributes[a.Type] = a
+		if a.Type == POSITION && a.Size > 0 {
+			m.vertices = len(a.Data) / a.Size
+		}
+	}
+
+	id := b.id()
+	b.meshes[id] = m
+	return id, nil
+}
+
+// DeleteMesh removes the mesh
+func (b *SoftwareBackend) DeleteMesh(id uint32, buffers []uint32) {
+	b.m.Lock()
+	delete(b.meshes, id)
+	b.m.Unlock()
+}
+
+// CreateTexture stores the image as texture
+func (b *SoftwareBackend) CreateTexture(img *image.RGBA) uint32 {
+	b.m.Lock()
+	defer b.m.Unlock()
+	id := b.id()
+	b.textures[id] = []*image.RGBA{img}
+	return id
+}
+
+// CreateCubeMap stores the images as the faces of a cube map
+func (b *SoftwareBackend) CreateCubeMap(faces [6]*image.RGBA) uint32 {
+	b.m.Lock()
+	defer b.m.Unlock()
+	id := b.id()
+	b.textures[id] = faces[:]
+	return id
+}
+
+// DeleteTexture removes the texture or cube map
+func (b *SoftwareBackend) DeleteTexture(id uint32) {
+	b.m.Lock()
+	delete(b.textures, id)
+	b.m.Unlock()
+}
+
+// CreateProgram returns a program that emulates the shaders, which must be one of the built-in shaders
+func (b *SoftwareBackend) CreateProgram(vertexShader, fragmentShader string) (uint32, error) {
+	shader, exists := softwareShaders[shaderKey(vertexShader, fragmentShader)]
+	if !exists {
+		return 0, ErrUnsupportedShader
+	}
+
+	b.m.Lock()
+	defer b.m.Unlock()
+	id := b.id()
+	b.programs[id] = &softwareProgram{shader: shader, uniforms: make(uniforms)}
+	return id, nil
+}
+
+// DeleteProgram removes the program
+func (b *SoftwareBackend) DeleteProgram(id uint32) {
+	b.m.Lock()
+	if p, exists := b.programs[id]; exists && p == b.program {
+		b.program = nil
+	}
+	delete(b.programs, id)
+	b.m.Unlock()
+}
+
+// UseProgram sets the program used by the next draws
+func (b *SoftwareBackend) UseProgram(id uint32) {
+	b.m.Lock()
+	b.program = b.programs[id]
+	b.m.Unlock()
+}
+
+// setUniform stores the value of a uniform of the program
+func (b *SoftwareBackend) setUniform(program uint32, name string, value any) {
+	b.m.Lock()
+	if p, exists := b.programs[program]; exists {
+		p.uniforms[name] = value
+	}
+	b.m.Unlock()
+}
+
+// SetFloat sets a float uniform of the program
+func (b *SoftwareBackend) SetFloat(program uint32, name string, value float32) {
+	b.setUniform(program, name, value)
+}
+
+// SetInt sets an integer uniform of the program
+func (b *SoftwareBackend) SetInt(program uint32, name string, value int32) {
+	b.setUniform(program, name, value)
+}
+
+// SetVec3 sets a vector uniform of the program
+func (b *SoftwareBackend) SetVec3(program uint32, name string, value [3]float32) {
+	b.setUniform(program, name, mgl32.Vec3(value))
+}
+
+// SetVec4 sets a vector uniform with 4 elements of the program
+func (b *SoftwareBackend) SetVec4(program uint32, name string, value [4]float32) {
+	b.setUniform(program, name, mgl32.Vec4(value))
+}
+
+// SetMat4 sets a matrix uniform of the program
+func (b *SoftwareBackend) SetMat4(program uint32, name string, value [16]float32) {
+	b.setUniform(program, name, mgl32.Mat4(value))
+}
+
+// BindTexture binds the texture to the texture unit
+func (b *SoftwareBackend) BindTexture(unit int, id uint32) {
+	b.m.Lock()
+	b.units[unit] = id
+	b.m.Unlock()
+}
+
+// SetState changes the state used by the next draws
+func (b *SoftwareBackend) SetState(state RenderState) {
+	b.m.Lock()
+	b.state = state
+	b.m.Unlock()
+}
+
+// Clear fills the image with the colour and resets the depth
+func (b *SoftwareBackend) Clear(c Colour) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	r, g, bl, a := toByte(c.R), toByte(c.G), toByte(c.B), toByte(c.A)
+	pix := b.frame.colour.Pix
+	for i := 0; i < len(pix); i += 4 {
+		pix[i], pix[i+1], pix[i+2], pix[i+3] = r, g, bl, a
+	}
+	for i := range b.frame.depth {
+		b.frame.depth[i] = 1
+	}
+}
+
+// CreateRenderTarget creates an image to render into, which is stored as texture as well.
+// Every format is stored using a byte per channel and the depth always as float
+func (b *SoftwareBackend) CreateRenderTarget(width, height int, format TextureFormat, depth DepthFormat) (uint32, uint32, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	t := newSoftwareTarget(width, height, depth != DepthNone)
+	t.flip = true
+	t.texture = b.id()
+	b.textures[t.texture] = []*image.RGBA{t.colour}
+
+	id := b.id()
+	b.targets[id] = t
+	return id, t.texture, nil
+}
+
+// DeleteRenderTarget removes the render target and its texture
+func (b *SoftwareBackend) DeleteRenderTarget(id uint32) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	t, exists := b.targets[id]
+	if !exists {
+		return
+	}
+	if b.frame == t {
+		b.frame = b.screen
+	}
+	delete(b.textures, t.texture)
+	delete(b.targets, id)
+}
+
+// BindRenderTarget sets the image the next clears and draws render into, 0 renders into the image returned by Image
+func (b *SoftwareBackend) BindRenderTarget(id uint32) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	if id == 0 {
+		b.frame = b.screen
+	} else if t, exists := b.targets[id]; exists {
+		b.frame = t
+	}
+}
+
+// ReadPixels returns a copy of the render target, or of the image returned by Image when id is 0, with its rows bottom up
+func (b *SoftwareBackend) ReadPixels(id uint32) (*image.RGBA, error) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	t := b.screen
+	if id != 0 {
+		var exists bool
+		if t, exists = b.targets[id]; !exists {
+			return nil, fmt.Errorf("rebound: unknown render target %d", id)
+		}
+	}
+
+	img := image.NewRGBA(t.colour.Rect)
+	copy(img.Pix, t.colour.Pix)
+	if !t.flip {
+		flipVertical(img)
+	}
+	return img, nil
+}
+
+// clipVertex is a vertex after the vertex stage, in clip space
+type clipVertex struct {
+	pos mgl32.Vec4
+	out varyings
+}
+
+// Draw shades the vertices of the mesh using the current program and rasterizes its triangles
+func (b *SoftwareBackend) Draw(mesh uint32, count int) {
+	b.m.Lock()
+	defer b.m.Unlock()
+
+	m, p := b.meshes[mesh], b.program
+	if m == nil || p == nil {
+		return
+	}
+
+	shaded := make([]*clipVertex, m.vertices)
+	vertex := func(i int) *clipVertex {
+		index := i
+		if len(m.indices) > 0 {
+			index = int(m.indices[i])
+		}
+		if index >= len(shaded) {
+			return nil
+		}
+		if shaded[index] == nil {
+			pos, out := p.shader.vertex(p.uniforms, vertexInput{m, index})
+			shaded[index] = &clipVertex{pos, out}
+		}
+		return shaded[index]
+	}
+
+	if len(m.indices) > 0 {
+		count = min(count, len(m.indices))
+	}
+	for i := 0; i+2 < count; i += 3 {
+		v0, v1, v2 := vertex(i), vertex(i+1), vertex(i+2)
+		if v0 == nil || v1 == nil || v2 == nil {
+			continue
+		}
+
+		polygon := clip([]clipVertex{*v0, *v1, *v2})
+		for j := 1; j+1 < len(polygon); j++ {
+			b.rasterize(p, polygon[0], polygon[j], polygon[j+1])
+		}
+	}
+}
+
+// clip clips the polygon against the near plane, which also removes every part behind the camera
+func clip(polygon []clipVertex) []clipVertex {
+	planes := []func(p mgl32.Vec4) float32{
+		func(p mgl32.Vec4) float32 { return p[2] + p[3] },
+		func(p mgl32.Vec4) float32 { return p[3] - 1e-5 },
+	}
+
+	for _, plane := range planes {
+		var out []clipVertex
+		for i := range polygon {
+			a, b := polygon[i], polygon[(i+1)%len(polygon)]
+			da, db := plane(a.pos), plane(b.pos)
+			if da >= 0 {
+				out = append(out, a)
+			}
+			if (da >= 0) != (db >= 0) {
+				t := da / (da - db)
+				v := clipVertex{pos: a.pos.Add(b.pos.Sub(a.pos).Mul(t))}
+				for k := range v.out {
+					v.out[k] = a.out[k] + (b.out[k]-a.out[k])*t
+				}
+				out = append(out, v)
+			}
+		}
+		polygon = out
+		if len(polygon) < 3 {
+			return nil
+		}
+	}
+	return polygon
+}
+
+// screenVertex is a vertex in window coordinates, where y points down, or up when rendering into a render target.
+// Vertices close to the camera can end up far outside of the window, so float64 is used to keep the precision
+type screenVertex struct {
+	x, y, z, invW float64
+	ndcX, ndcY    float64
+}
+
+func (b *SoftwareBackend) toScreen(v clipVertex) screenVertex {
+	w, h := float64(b.frame.colour.Rect.Dx()), float64(b.frame.colour.Rect.Dy())
+	invW := 1 / float64(v.pos[3])
+	x, y, z := float64(v.pos[0])*invW, float64(v.pos[1])*invW, float64(v.pos[2])*invW
+	screenY := (1 - y) / 2 * h
+	if b.frame.flip {
+		screenY = (y + 1) / 2 * h
+	}
+	return screenVertex{
+		x:    (x + 1) / 2 * w,
+		y:    screenY,
+		z:    z*0.5 + 0.5,
+		invW: invW,
+		ndcX: x,
+		ndcY: y,
+	}
+}
+
+// edge returns twice the signed area of the triangle a, b, p
+func edge(a, b screenVertex, x, y float64) float64 {
+	return (x-a.x)*(b.y-a.y) - (y-a.y)*(b.x-a.x)
+}
+
+// rasterize draws a triangle, the caller must hold the lock of the backend
+func (b *SoftwareBackend) rasterize(p *softwareProgram, v0, v1, v2 clipVertex) {
+	s := [3]screenVertex{b.toScreen(v0), b.toScreen(v1), b.toScreen(v2)}
+	out := [3]*varyings{&v0.out, &v1.out, &v2.out}
+
+	// Counter clockwise triangles face the camera, using the y up coordinates of OpenGL
+	area := (s[1].ndcX-s[0].ndcX)*(s[2].ndcY-s[0].ndcY) - (s[2].ndcX-s[0].ndcX)*(s[1].ndcY-s[0].ndcY)
+	if area == 0 || (b.state.CullFace && area < 0) {
+		return
+	}
+
+	total := edge(s[0], s[1], s[2].x, s[2].y)
+	if total == 0 {
+		return
+	}
+
+	frame := b.frame
+	width, height := frame.colour.Rect.Dx(), frame.colour.Rect.Dy()
+	minX := max(0, int(math.Floor(min(s[0].x, s[1].x, s[2].x))))
+	maxX := min(width-1, int(math.Ceil(max(s[0].x, s[1].x, s[2].x))))
+	minY := max(0, int(math.Floor(min(s[0].y, s[1].y, s[2].y))))
+	maxY := min(height-1, int(math.Ceil(max(s[0].y, s[1].y, s[2].y))))
+
+	// the distance in pixels from a vertex to its opposite edge, used to draw the edges in wireframe mode
+	var heights [3]float64
+	if b.state.Wireframe {
+		for i := range heights {
+			a, c := s[(i+1)%3], s[(i+2)%3]
+			heights[i] = math.Abs(total) / math.Hypot(c.x-a.x, c.y-a.y)
+		}
+	}
+
+	// a triangle at a constant depth, such as the skybox, keeps its exact depth
+	flat := s[0].z == s[1].z && s[1].z == s[2].z
+
+	smp := sampler{b}
+	for y := minY; y <= maxY; y++ {
+		for x := minX; x <= maxX; x++ {
+			px, py := float64(x)+0.5, float64(y)+0.5
+			w := [3]float64{
+				edge(s[1], s[2], px, py) / total,
+				edge(s[2], s[0], px, py) / total,
+				edge(s[0], s[1], px, py) / total,
+			}
+			if w[0] < 0 || w[1] < 0 || w[2] < 0 {
+				continue
+			}
+			if b.state.Wireframe && w[0]*heights[0] >= 1 && w[1]*heights[1] >= 1 && w[2]*heights[2] >= 1 {
+				continue
+			}
+
+			z := s[0].z
+			if !flat {
+				z = w[0]*s[0].z + w[1]*s[1].z + w[2]*s[2].z
+			}
+			if z > 1 {
+				continue
+			}
+			depth := float32(max(z, 0))
+
+			i := y*width + x
+			depthTest := b.state.DepthTest && frame.depth != nil
+			if depthTest {
+				if b.state.DepthFunc == DepthLessEqual && depth > frame.depth[i] || b.state.DepthFunc == DepthLess && depth >= frame.depth[i] {
+					continue
+				}
+			}
+
+			// interpolate perspective correct
+			var in varyings
+			pw := w[0]*s[0].invW + w[1]*s[1].invW + w[2]*s[2].invW
+			for k := range in {
+				in[k] = float32((w[0]*float64(out[0][k])*s[0].invW + w[1]*float64(out[1][k])*s[1].invW + w[2]*float64(out[2][k])*s[2].invW) / pw)
+			}
+
+			c, ok := p.shader.fragment(p.uniforms, in, smp)
+			if !ok {
+				continue
+			}
+
+			if depthTest {
+				frame.depth[i] = depth
+			}
+			o := frame.colour.PixOffset(x, y)
+			frame.colour.Pix[o], frame.colour.Pix[o+1], frame.colour.Pix[o+2], frame.colour.Pix[o+3] = toByte(c[0]), toByte(c[1]), toByte(c[2]), toByte(c[3])
+		}
+	}
+}
+
+// toByte converts a colour channel from 0-1 to 0-255
+func toByte(v float32) uint8 {
+	return uint8(mgl32.Clamp(v, 0, 1)*255 + 0.5)
+}
+
+// shaderKey identifies a shader program by its sources
+func shaderKey(vertexShader, fragmentShader string) string {
+	return strings.TrimRight(vertexShader, "\x00") + "\x00" + strings.TrimRight(fragmentShader, "\x00")
+}
@@ -0,0 +1,149 @@
+package rebound
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/luukdegram/rebound/ecs"
+)
+
+// newSoftwareRenderer returns a RenderSystem rendering into a new SoftwareBackend, with the camera at 0, 0, 3
+func newSoftwareRenderer(t *testing.T, width, height int) (*RenderSystem, *SoftwareBackend) {
+	t.Helper()
+	b := NewSoftwareBackend(width, height)
+	SetBackend(b)
+	t.Cleanup(func() { SetBackend(nil) })
+
+	rs, err := NewRenderSystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs.NewCamera(width, height)
+	rs.Camera.Position = [3]float32{0, 0, 3}
+	return rs, b
+}
+
+// newQuad returns a textured square of 2 by 2 facing +Z
+func newQuad(texture uint32) *Mesh {
+	m := &Mesh{
+		Indices: []uint32{0, 1, 2, 2, 3, 0},
+		Attributes: []Attribute{
+			{Type: POSITION, Size: 3, Data: []float32{-1, -1, 0, 1, -1, 0, 1, 1, 0, -1, 1, 0}},
+			{Type: NORMALS, Size: 3, Data: []float32{0, 0, 1, 0, 0, 1, 0, 0, 1, 0, 0, 1}},
+			{Type: TEXCOORDS0, Size: 2, Data: []float32{0, 1, 1, 1, 1, 0, 0, 0}},
+		},
+		Material: &Material{PBRMetallicRoughness: PBRMetallicRoughness{BaseColorTexture: &texture}},
+	}
+	LoadMesh(m)
+	return m
+}
+
+// newSolidTexture creates a texture of a single colour
+func newSolidTexture(c color.RGBA) uint32 {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+	return CurrentBackend().CreateTexture(img)
+}
+
+func TestSoftwareBackendRender(t *testing.T) {
+	rs, b := newSoftwareRenderer(t, 32, 32)
+	quad := newQuad(newSolidTexture(color.RGBA{255, 0, 0, 255}))
+	rs.AddEntities(ecs.NewEntity(&RenderComponent{Mesh: quad, Scale: [3]float32{1, 1, 1}}))
+
+	rs.Update(0)
+	img := b.Image()
+
+	background := color.RGBA{toByte(rs.BaseColour.R), toByte(rs.BaseColour.G), toByte(rs.BaseColour.B), 255}
+	if c := img.RGBAAt(0, 0); c != background {
+		t.Errorf("Render failed. Expected the background %v in the corner, but got %v", background, c)
+	}
+	if c := img.RGBAAt(16, 16); c.R == 0 || c.R <= c.G || c.G != c.B {
+		t.Errorf("Render failed. Expected a lit red quad in the center, but got %v", c)
+	}
+}
+
+func TestSoftwareBackendCulling(t *testing.T) {
+	rs, b := newSoftwareRenderer(t, 32, 32)
+	quad := newQuad(newSolidTexture(color.RGBA{255, 0, 0, 255}))
+	rs.AddEntities(ecs.NewEntity(&RenderComponent{Mesh: quad, Rotation: [3]float32{0, 180, 0}, Scale: [3]float32{1, 1, 1}}))
+
+	rs.Update(0)
+	if c := b.Image().RGBAAt(16, 16); c.R == 255 || c.R > c.G {
+		t.Errorf("Render failed. Expected the back of the quad to be culled, but got %v", c)
+	}
+
+	quad.Material.Transparent = true
+	rs.Update(0)
+	if c := b.Image().RGBAAt(16, 16); c.R <= c.G {
+		t.Errorf("Render failed. Expected the back of a transparent quad to be drawn, but got %v", c)
+	}
+}
+
+func TestSoftwareBackendDepth(t *testing.T) {
+	rs, b := newSoftwareRenderer(t, 32, 32)
+	red := newQuad(newSolidTexture(color.RGBA{255, 0, 0, 255}))
+	green := newQuad(newSolidTexture(color.RGBA{0, 255, 0, 255}))
+	rs.AddEntities(
+		ecs.NewEntity(&RenderComponent{Mesh: red, Position: [3]float32{0, 0, 1}, Scale: [3]float32{1, 1, 1}}),
+		ecs.NewEntity(&RenderComponent{Mesh: green, Scale: [3]float32{1, 1, 1}}),
+	)
+
+	rs.Update(0)
+	if c := b.Image().RGBAAt(16, 16); c.R <= c.G {
+		t.Errorf("Render failed. Expected the closest quad to be visible, but got %v", c)
+	}
+}
+
+func TestSoftwareBackendUnsupportedShader(t *testing.T) {
+	if _, err := NewSoftwareBackend(1, 1).CreateProgram("void main() {}", "void main() {}"); err != ErrUnsupportedShader {
+		t.Errorf("CreateProgram failed. Expected %v, but got %v", ErrUnsupportedShader, err)
+	}
+}
+
+func TestSoftwareBackendRenderTarget(t *testing.T) {
+	rs, b := newSoftwareRenderer(t, 32, 32)
+	target, err := NewRenderTarget(32, 32, FormatRGBA8, Depth24)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the view looks backwards at a red quad above the center, which the camera cannot see
+	view := target.NewCamera()
+	view.Yaw = 180
+	rs.Views = []*Camera{view}
+	red := newQuad(newSolidTexture(color.RGBA{255, 0, 0, 255}))
+	screen := newQuad(0)
+	screen.Material = target.Material()
+	rs.AddEntities(
+		ecs.NewEntity(&RenderComponent{Mesh: red, Position: [3]float32{0, 1, 3}, Rotation: [3]float32{0, 180, 0}, Scale: [3]float32{2, 1, 1}}),
+		ecs.NewEntity(&RenderComponent{Mesh: screen, Scale: [3]float32{1, 1, 1}}),
+	)
+
+	rs.Update(0)
+
+	// the rows of the texture are stored bottom up
+	texture := b.textures[target.Texture][0]
+	if c := texture.RGBAAt(16, 20); c.R <= c.G {
+		t.Errorf("Render failed. Expected the red quad in the upper half of the target, but got %v", c)
+	}
+	if c := texture.RGBAAt(16, 8); c.R > c.G {
+		t.Errorf("Render failed. Expected the background in the lower half of the target, but got %v", c)
+	}
+
+	img := b.Image()
+	if c := img.RGBAAt(16, 18); c.R <= c.G {
+		t.Errorf("Render failed. Expected the texture of the target on the screen, but got %v", c)
+	}
+	if c := img.RGBAAt(16, 12); c.R > c.G {
+		t.Errorf("Render failed. Expected the background of the target on the screen, but got %v", c)
+	}
+
+	id := target.Texture
+	target.Delete()
+	if _, exists := b.textures[id]; exists {
+		t.Errorf("Delete failed. Expected the texture of the target to be removed")
+	}
+}
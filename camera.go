@@ -3,9 +3,11 @@ package rebound
 import "github.com/go-gl/mathgl/mgl32"
 
 //Camera handles the camera of the scene
+//When Target is set, the camera renders into the [RenderTarget] instead of the window
 type Camera struct {
 	Position   [3]float32
 	Projection [16]float32
+	Target     *RenderTarget
 	Pitch,
 	Yaw,
 	Roll,
@@ -0,0 +1,331 @@
+package rebound
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/luukdegram/rebound/internal/thread"
+)
+
+var (
+	// ErrCapturing is returned by [StartCapture] when a capture is already running
+	ErrCapturing = errors.New("rebound: already capturing")
+	// ErrFrameSize is returned by [Capture.WriteFrame] when the size of a frame differs from the first frame of a Y4M stream
+	ErrFrameSize = errors.New("rebound: frame size changed during capture")
+)
+
+// Screenshot renders the current frame and reads it back before it is shown.
+// The image contains the Target of the RenderSystem or its Camera when set, otherwise the window, which is always opaque.
+// The Views are not part of the image, use [RenderSystem.ScreenshotView] to read back a view such as a minimap
+func (rs *RenderSystem) Screenshot() (*image.RGBA, error) {
+	if rs.Camera == nil {
+		return nil, errors.New("rebound: screenshot requires a camera")
+	}
+	return rs.screenshot(rs.Camera, rs.target())
+}
+
+// ScreenshotView renders the entities as seen by the camera, i.e. one of the Views, and reads the result back.
+// The image contains the Target of the camera when set, otherwise the window, which is always opaque
+func (rs *RenderSystem) ScreenshotView(camera *Camera) (*image.RGBA, error) {
+	if camera == nil {
+		return nil, errors.New("rebound: screenshot requires a camera")
+	}
+	return rs.screenshot(camera, camera.Target)
+}
+
+// screenshot renders the camera into the target, or the window when target is nil, and reads the result back
+func (rs *RenderSystem) screenshot(camera *Camera, target *RenderTarget) (*image.RGBA, error) {
+	var img *image.RGBA
+	err := thread.CallErr(func() error {
+		rs.draw(camera, target)
+		defer CurrentBackend().BindRenderTarget(0)
+
+		var err error
+		img, err = CurrentBackend().ReadPixels(target.id())
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	flipVertical(img)
+	if target == nil {
+		opaque(img)
+	}
+	return img, nil
+}
+
+// SaveScreenshot takes a [RenderSystem.Screenshot] and saves it inside the directory, named after the current time.
+// It returns the path of the file, which makes it easy to bind to a key
+func (rs *RenderSystem) SaveScreenshot(dir string) (string, error) {
+	img, err := rs.Screenshot()
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, time.Now().Format("screenshot-20060102-150405.000.png"))
+	return path, SavePNG(path, img)
+}
+
+// SavePNG encodes the image as PNG into the file, creating its directory when it does not exist
+func SavePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// flipVertical reverses the rows of the image, i.e. to convert between the bottom left origin of OpenGL and the top left origin of images
+func flipVertical(img *image.RGBA) {
+	h := img.Rect.Dy()
+	row := make([]byte, img.Rect.Dx()*4)
+	for y := 0; y < h/2; y++ {
+		top := img.Pix[y*img.Stride : y*img.Stride+len(row)]
+		bottom := img.Pix[(h-1-y)*img.Stride : (h-1-y)*img.Stride+len(row)]
+		copy(row, top)
+		copy(top, bottom)
+		copy(bottom, row)
+	}
+}
+
+// opaque sets the alpha of every pixel to 255, as the window is shown without transparency
+func opaque(img *image.RGBA) {
+	for i := 3; i < len(img.Pix); i += 4 {
+		img.Pix[i] = 255
+	}
+}
+
+// CaptureFormat is the format a [Capture] writes its frames in
+type CaptureFormat int
+
+const (
+	// CapturePNG writes every frame to a numbered PNG file inside the directory of the capture, such as frame_000001.png
+	CapturePNG CaptureFormat = iota
+	// CaptureY4M writes every frame to an uncompressed YUV4MPEG2 video stream, using 4:2:0 chroma subsampling
+	CaptureY4M
+)
+
+// CaptureOptions configures a [Capture].
+// Path is the directory of a PNG sequence or the file of a Y4M stream.
+// FPS is the number of frames per second, 60 when not set
+type CaptureOptions struct {
+	Format CaptureFormat
+	Path   string
+	FPS    int
+}
+
+// Capture writes a sequence of frames, i.e. to record a trailer or a bug report.
+// While a capture started by [StartCapture] runs, [Run] advances time by exactly one frame of the capture per frame,
+// so the recording plays at the right speed regardless of how long a frame takes to render
+type Capture struct {
+	m       sync.Mutex
+	options CaptureOptions
+	frames  int
+	file    *os.File
+	w       *bufio.Writer
+	size    image.Point
+	closed  bool
+}
+
+// NewCapture starts a capture, creating its directory or file
+func NewCapture(options CaptureOptions) (*Capture, error) {
+	if options.FPS <= 0 {
+		options.FPS = 60
+	}
+	c := &Capture{options: options}
+
+	switch options.Format {
+	case CapturePNG:
+		if err := os.MkdirAll(options.Path, 0755); err != nil {
+			return nil, err
+		}
+	case CaptureY4M:
+		if err := os.MkdirAll(filepath.Dir(options.Path), 0755); err != nil {
+			return nil, err
+		}
+		f, err := os.Create(options.Path)
+		if err != nil {
+			return nil, err
+		}
+		c.file, c.w = f, bufio.NewWriter(f)
+	default:
+		return nil, fmt.Errorf("rebound: unknown capture format %d", options.Format)
+	}
+	return c, nil
+}
+
+// Step returns the time in seconds between two frames of the capture
+func (c *Capture) Step() float64 {
+	return 1 / float64(c.options.FPS)
+}
+
+// Frames returns the number of frames written
+func (c *Capture) Frames() int {
+	c.m.Lock()
+	defer c.m.Unlock()
+	return c.frames
+}
+
+// WriteFrame writes the image as next frame of the capture
+func (c *Capture) WriteFrame(img *image.RGBA) error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.closed {
+		return os.ErrClosed
+	}
+
+	var err error
+	if c.options.Format == CapturePNG {
+		err = SavePNG(filepath.Join(c.options.Path, fmt.Sprintf("frame_%06d.png", c.frames+1)), img)
+	} else {
+		err = c.writeY4M(img)
+	}
+	if err != nil {
+		return err
+	}
+	c.frames++
+	return nil
+}
+
+// writeY4M writes the stream header before the first frame, followed by the frame in the Y, Cb and Cr planes
+func (c *Capture) writeY4M(img *image.RGBA) error {
+	size := img.Rect.Size()
+	if c.frames == 0 {
+		c.size = size
+		if _, err := fmt.Fprintf(c.w, "YUV4MPEG2 W%d H%d F%d:1 Ip A1:1 C420jpeg XCOLORRANGE=FULL\n", size.X, size.Y, c.options.FPS); err != nil {
+			return err
+		}
+	} else if size != c.size {
+		return ErrFrameSize
+	}
+
+	cw, ch := (size.X+1)/2, (size.Y+1)/2
+	luma := make([]byte, size.X*size.Y)
+	cb, cr := make([]byte, cw*ch), make([]byte, cw*ch)
+	for y := 0; y < ch; y++ {
+		for x := 0; x < cw; x++ {
+			// every chroma sample is the average of the block of 2 by 2 pixels it covers
+			var sumCb, sumCr, n int
+			for dy := 0; dy < 2 && 2*y+dy < size.Y; dy++ {
+				for dx := 0; dx < 2 && 2*x+dx < size.X; dx++ {
+					px, py := 2*x+dx, 2*y+dy
+					rgba := img.RGBAAt(img.Rect.Min.X+px, img.Rect.Min.Y+py)
+					l, u, v := color.RGBToYCbCr(rgba.R, rgba.G, rgba.B)
+					luma[py*size.X+px] = l
+					sumCb, sumCr, n = sumCb+int(u), sumCr+int(v), n+1
+				}
+			}
+			cb[y*cw+x] = uint8((sumCb + n/2) / n)
+			cr[y*cw+x] = uint8((sumCr + n/2) / n)
+		}
+	}
+
+	for _, b := range [][]byte{[]byte("FRAME\n"), luma, cb, cr} {
+		if _, err := c.w.Write(b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close finishes the capture, flushing the Y4M stream
+func (c *Capture) Close() error {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	if c.file == nil {
+		return nil
+	}
+	err := c.w.Flush()
+	if cerr := c.file.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+var (
+	capture      *Capture
+	captureMutex sync.Mutex
+)
+
+// StartCapture starts capturing every frame of the window shown by [Run], see [Capture]
+func StartCapture(options CaptureOptions) error {
+	captureMutex.Lock()
+	defer captureMutex.Unlock()
+	if capture != nil {
+		return ErrCapturing
+	}
+
+	c, err := NewCapture(options)
+	if err != nil {
+		return err
+	}
+	capture = c
+	return nil
+}
+
+// StopCapture stops the running capture, it does nothing when no capture runs
+func StopCapture() error {
+	captureMutex.Lock()
+	c := capture
+	capture = nil
+	captureMutex.Unlock()
+
+	if c == nil {
+		return nil
+	}
+	return c.Close()
+}
+
+// Capturing returns whether a capture started by [StartCapture] runs
+func Capturing() bool {
+	return activeCapture() != nil
+}
+
+// activeCapture returns the capture started by StartCapture, or nil
+func activeCapture() *Capture {
+	captureMutex.Lock()
+	defer captureMutex.Unlock()
+	return capture
+}
+
+// captureFrame reads back the window and writes it to the running capture, if any
+func captureFrame() error {
+	c := activeCapture()
+	if c == nil {
+		return nil
+	}
+
+	var img *image.RGBA
+	err := thread.CallErr(func() error {
+		var err error
+		img, err = CurrentBackend().ReadPixels(0)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	flipVertical(img)
+	opaque(img)
+	return c.WriteFrame(img)
+}
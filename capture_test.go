@@ -0,0 +1,168 @@
+package rebound
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luukdegram/rebound/ecs"
+)
+
+func TestScreenshot(t *testing.T) {
+	rs, b := newSoftwareRenderer(t, 32, 32)
+	quad := newQuad(newSolidTexture(color.RGBA{255, 0, 0, 255}))
+	rs.AddEntities(ecs.NewEntity(&RenderComponent{Mesh: quad, Position: [3]float32{0, 1, 0}, Scale: [3]float32{1, 1, 1}}))
+
+	img, err := rs.Screenshot()
+	if err != nil {
+		t.Fatalf("Screenshot failed. Unexpected error: %v", err)
+	}
+	if !bytes.Equal(img.Pix, b.Image().Pix) {
+		t.Errorf("Screenshot failed. Expected the screenshot to equal the rendered image")
+	}
+	if c := img.RGBAAt(16, 12); c.R <= c.G || c.A != 255 {
+		t.Errorf("Screenshot failed. Expected an opaque red quad above the center, but got %v", c)
+	}
+	if c := img.RGBAAt(16, 24); c.R > c.G {
+		t.Errorf("Screenshot failed. Expected the background below the center, but got %v", c)
+	}
+
+	rs.Target, err = NewRenderTarget(16, 8, FormatRGBA8, Depth24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img, err = rs.Screenshot(); err != nil || img.Rect.Size() != image.Pt(16, 8) {
+		t.Errorf("Screenshot failed. Expected an image of the target of 16x8, but got %v and error %v", img.Rect.Size(), err)
+	}
+}
+
+func TestScreenshotView(t *testing.T) {
+	rs, _ := newSoftwareRenderer(t, 32, 32)
+	quad := newQuad(newSolidTexture(color.RGBA{255, 0, 0, 255}))
+	rs.AddEntities(ecs.NewEntity(&RenderComponent{Mesh: quad, Scale: [3]float32{1, 1, 1}}))
+
+	target, err := NewRenderTarget(8, 8, FormatRGBA8, Depth24)
+	if err != nil {
+		t.Fatal(err)
+	}
+	minimap := target.NewCamera()
+	minimap.Position = [3]float32{0, 0, 3}
+	rs.Views = append(rs.Views, minimap)
+
+	img, err := rs.ScreenshotView(minimap)
+	if err != nil {
+		t.Fatalf("ScreenshotView failed. Unexpected error: %v", err)
+	}
+	if img.Rect.Size() != image.Pt(8, 8) {
+		t.Errorf("ScreenshotView failed. Expected an image of the view of 8x8, but got %v", img.Rect.Size())
+	}
+	if c := img.RGBAAt(4, 4); c.R <= c.G {
+		t.Errorf("ScreenshotView failed. Expected the red quad in the center of the view, but got %v", c)
+	}
+}
+
+func TestSaveScreenshot(t *testing.T) {
+	rs, _ := newSoftwareRenderer(t, 8, 8)
+	dir := filepath.Join(t.TempDir(), "screenshots")
+
+	path, err := rs.SaveScreenshot(dir)
+	if err != nil {
+		t.Fatalf("SaveScreenshot failed. Unexpected error: %v", err)
+	}
+	if filepath.Dir(path) != dir {
+		t.Errorf("SaveScreenshot failed. Expected the file inside %v, but got %v", dir, path)
+	}
+	if _, err := loadTextureData(path); err != nil {
+		t.Errorf("SaveScreenshot failed. Expected a readable PNG, but got %v", err)
+	}
+}
+
+// newFrame returns an image of the given size and colour
+func newFrame(w, h int, c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for i := 0; i < len(img.Pix); i += 4 {
+		img.Pix[i], img.Pix[i+1], img.Pix[i+2], img.Pix[i+3] = c.R, c.G, c.B, c.A
+	}
+	return img
+}
+
+func TestCapturePNG(t *testing.T) {
+	dir := t.TempDir()
+	c, err := NewCapture(CaptureOptions{Format: CapturePNG, Path: dir, FPS: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Step() != 1.0/30 {
+		t.Errorf("Step failed. Expected %v, but got %v", 1.0/30, c.Step())
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := c.WriteFrame(newFrame(4, 2, color.RGBA{255, 255, 255, 255})); err != nil {
+			t.Fatalf("WriteFrame failed. Unexpected error: %v", err)
+		}
+	}
+	c.Close()
+
+	for i := 1; i <= 2; i++ {
+		img, err := loadTextureData(filepath.Join(dir, fmt.Sprintf("frame_%06d.png", i)))
+		if err != nil || img.Rect.Size() != image.Pt(4, 2) {
+			t.Errorf("WriteFrame failed. Expected frame %d of 4x2, but got error %v", i, err)
+		}
+	}
+	if err := c.WriteFrame(newFrame(4, 2, color.RGBA{})); !errors.Is(err, os.ErrClosed) {
+		t.Errorf("WriteFrame failed. Expected %v after Close, but got %v", os.ErrClosed, err)
+	}
+}
+
+func TestCaptureY4M(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.y4m")
+	c, err := NewCapture(CaptureOptions{Format: CaptureY4M, Path: path})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := c.WriteFrame(newFrame(3, 3, color.RGBA{255, 255, 255, 255})); err != nil {
+			t.Fatalf("WriteFrame failed. Unexpected error: %v", err)
+		}
+	}
+	if err := c.WriteFrame(newFrame(4, 4, color.RGBA{})); err != ErrFrameSize {
+		t.Errorf("WriteFrame failed. Expected %v, but got %v", ErrFrameSize, err)
+	}
+	if err := c.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header := "YUV4MPEG2 W3 H3 F60:1 Ip A1:1 C420jpeg XCOLORRANGE=FULL\n"
+	frame := append([]byte("FRAME\n"), bytes.Repeat([]byte{255}, 9)...)
+	frame = append(frame, bytes.Repeat([]byte{128}, 8)...)
+	expect := append([]byte(header), append(frame, frame...)...)
+	if !bytes.Equal(data, expect) {
+		t.Errorf("WriteFrame failed. Expected %q, but got %q", expect, data)
+	}
+}
+
+func TestStartCapture(t *testing.T) {
+	options := CaptureOptions{Format: CapturePNG, Path: t.TempDir()}
+	if err := StartCapture(options); err != nil {
+		t.Fatal(err)
+	}
+	if err := StartCapture(options); err != ErrCapturing {
+		t.Errorf("StartCapture failed. Expected %v, but got %v", ErrCapturing, err)
+	}
+	if !Capturing() {
+		t.Errorf("Capturing failed. Expected a running capture")
+	}
+	if err := StopCapture(); err != nil || Capturing() {
+		t.Errorf("StopCapture failed. Expected no running capture, but got error %v", err)
+	}
+}
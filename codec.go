@@ -0,0 +1,212 @@
+package rebound
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/luukdegram/rebound/ecs"
+)
+
+// ErrNoMeshSource is returned when saving a [RenderComponent] whose [Mesh] was not loaded from an asset
+var ErrNoMeshSource = errors.New("rebound: mesh has no source")
+
+func init() {
+	ecs.RegisterJSON[*Transform](TransformComponentName)
+	ecs.RegisterComponent(RenderComponentName, renderCodec{})
+}
+
+// renderData is the serialized form of a RenderComponent, which references its mesh and textures by their asset paths
+type renderData struct {
+	Mesh             string        `json:"mesh"`
+	Material         *materialData `json:"material,omitempty"`
+	MaterialOverride *materialData `json:"materialOverride,omitempty"`
+	Rotation         [3]float32    `json:"rotation"`
+	Position         [3]float32    `json:"position"`
+	Scale            [3]float32    `json:"scale"`
+}
+
+// materialData is the serialized form of a Material
+type materialData struct {
+	Transparent              bool       `json:"transparent"`
+	BaseColor                [4]float32 `json:"baseColor"`
+	MetallicFactor           float32    `json:"metallicFactor"`
+	RoughnessFactor          float32    `json:"roughnessFactor"`
+	EmissiveFactor           [3]float32 `json:"emissiveFactor"`
+	BaseColorTexture         string     `json:"baseColorTexture,omitempty"`
+	MetallicRoughnessTexture string     `json:"metallicRoughnessTexture,omitempty"`
+	NormalTexture            string     `json:"normalTexture,omitempty"`
+	OcclusionTexture         string     `json:"occlusionTexture,omitempty"`
+	EmmisiveTexture          string     `json:"emmisiveTexture,omitempty"`
+}
+
+// renderCodec serializes a RenderComponent using the source of its mesh and the paths of its textures
+type renderCodec struct{}
+
+func (renderCodec) Encode(c ecs.Component) ([]byte, error) {
+	rc := c.(*RenderComponent)
+	if rc.Mesh == nil || rc.Source == "" {
+		return nil, ErrNoMeshSource
+	}
+	data, err := encodeRender(rc, rc.Source, false)
+	if err != nil {
+		return nil, err
+	}
+	if data.Material, err = encodeMaterial(rc.Material, false); err != nil {
+		return nil, fmt.Errorf("%w of mesh %s", err, rc.Source)
+	}
+	return json.Marshal(data)
+}
+
+// EncodeSnapshot encodes the component like Encode, but references meshes and textures without a file by their memory,
+// so entities created in code can be restored within the same process.
+// The material of the mesh is not encoded, as the mesh is shared in memory and keeps its material
+func (renderCodec) EncodeSnapshot(c ecs.Component) ([]byte, error) {
+	rc := c.(*RenderComponent)
+	if rc.Mesh == nil {
+		return nil, ErrNoMeshSource
+	}
+	source := rc.Source
+	if source == "" {
+		source = memorySource(rc.Mesh)
+	}
+	data, err := encodeRender(rc, source, true)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(data)
+}
+
+// encodeRender returns the serialized form of the RenderComponent without the material of its mesh, referencing the mesh by source
+func encodeRender(rc *RenderComponent, source string, memory bool) (renderData, error) {
+	var err error
+	data := renderData{
+		Mesh:     source,
+		Rotation: rc.Rotation,
+		Position: rc.Position,
+		Scale:    rc.Scale,
+	}
+	if data.MaterialOverride, err = encodeMaterial(rc.MaterialOverride, memory); err != nil {
+		return renderData{}, fmt.Errorf("%w of mesh %s", err, source)
+	}
+	return data, nil
+}
+
+// Decode loads the mesh from its source, the saved material becomes the MaterialOverride of the component.
+// The mesh is shared with every other entity that uses its source, so its own material is left unchanged
+func (renderCodec) Decode(raw []byte) (ecs.Component, error) {
+	var data renderData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return nil, err
+	}
+
+	mesh, err := LoadMeshSource(data.Mesh)
+	if err != nil {
+		return nil, err
+	}
+
+	saved := data.MaterialOverride
+	if saved == nil {
+		saved = data.Material
+	}
+	override, err := decodeMaterial(saved)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenderComponent{
+		Mesh:             mesh,
+		Rotation:         data.Rotation,
+		Position:         data.Position,
+		Scale:            data.Scale,
+		MaterialOverride: override,
+	}, nil
+}
+
+// encodeMaterial returns the serialized form of the material, where its textures are replaced by their files.
+// When memory is set, textures that were not loaded from a file are referenced by their id, see memoryTexture
+func encodeMaterial(m *Material, memory bool) (*materialData, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	data := &materialData{
+		Transparent:     m.Transparent,
+		BaseColor:       m.BaseColor,
+		MetallicFactor:  m.MetallicFactor,
+		RoughnessFactor: m.RoughnessFactor,
+		EmissiveFactor:  m.EmissiveFactor,
+	}
+	textures := []struct {
+		id   *uint32
+		path *string
+	}{
+		{m.BaseColorTexture, &data.BaseColorTexture},
+		{m.MetallicRoughnessTexture, &data.MetallicRoughnessTexture},
+		{m.NormalTexture, &data.NormalTexture},
+		{m.OcclusionTexture, &data.OcclusionTexture},
+		{m.EmmisiveTexture, &data.EmmisiveTexture},
+	}
+	for _, t := range textures {
+		if t.id == nil {
+			continue
+		}
+		file, ok := TexturePath(*t.id)
+		if !ok && memory {
+			file, ok = fmt.Sprintf("%s%d", memoryTexture, *t.id), true
+		}
+		if !ok {
+			return nil, fmt.Errorf("rebound: texture %d was not loaded from a file", *t.id)
+		}
+		*t.path = file
+	}
+	return data, nil
+}
+
+// decodeMaterial creates a material from its serialized form, loading its textures from their files
+func decodeMaterial(data *materialData) (*Material, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	m := &Material{Transparent: data.Transparent}
+	m.BaseColor = data.BaseColor
+	m.MetallicFactor = data.MetallicFactor
+	m.RoughnessFactor = data.RoughnessFactor
+	m.EmissiveFactor = data.EmissiveFactor
+	textures := []struct {
+		path string
+		id   **uint32
+	}{
+		{data.BaseColorTexture, &m.BaseColorTexture},
+		{data.MetallicRoughnessTexture, &m.MetallicRoughnessTexture},
+		{data.NormalTexture, &m.NormalTexture},
+		{data.OcclusionTexture, &m.OcclusionTexture},
+		{data.EmmisiveTexture, &m.EmmisiveTexture},
+	}
+	for _, t := range textures {
+		if t.path == "" {
+			continue
+		}
+		id, err := decodeTexture(t.path)
+		if err != nil {
+			return nil, err
+		}
+		*t.id = &id
+	}
+	return m, nil
+}
+
+// memoryTexture prefixes the id of a texture that was not loaded from a file within a [ecs.Snapshot]
+const memoryTexture = "memory#"
+
+// decodeTexture loads the texture from its file, or returns the id of a texture referenced by its memory
+func decodeTexture(path string) (uint32, error) {
+	if id, ok := strings.CutPrefix(path, memoryTexture); ok {
+		texture, err := strconv.ParseUint(id, 10, 32)
+		return uint32(texture), err
+	}
+	return LoadTexture(path)
+}
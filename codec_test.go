@@ -0,0 +1,98 @@
+package rebound
+
+import (
+	"bytes"
+	"errors"
+	"image/color"
+	"testing"
+
+	"github.com/luukdegram/rebound/ecs"
+)
+
+func TestSaveLoadScene(t *testing.T) {
+	RegisterMeshLoader(".test", func(source string) (*Mesh, error) {
+		return &Mesh{Indices: []uint32{0, 1, 2}}, nil
+	})
+
+	w := ecs.NewWorld()
+	transform := NewTransform()
+	transform.Position = [3]float32{1, 2, 3}
+	transform.SetEuler([3]float32{0, 90, 0})
+	mesh := &Mesh{Source: "cube.test#0", Material: &Material{}}
+	mesh.Material.BaseColor = [4]float32{1, 0, 0, 1}
+	mesh.Material.RoughnessFactor = 0.5
+	mesh.Material.EmissiveFactor = [3]float32{0, 0, 1}
+	e := w.NewEntity(transform, &RenderComponent{Mesh: mesh, Scale: [3]float32{1, 1, 1}})
+
+	var buf bytes.Buffer
+	if err := ecs.Save(w, &buf); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := ecs.Load(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	le := loaded.Entity(e.ID())
+	if le == nil {
+		t.Fatalf("Load failed. Expected entity with ID %s", e.ID())
+	}
+	lt, ok := ecs.Get[*Transform](le)
+	if !ok || lt.Position != transform.Position || !lt.Rotation.ApproxEqual(transform.Rotation) {
+		t.Errorf("Load failed. Expected transform %v, but got %v", transform, lt)
+	}
+	rc, ok := ecs.Get[*RenderComponent](le)
+	if !ok || rc.Source != mesh.Source || rc.VertexCount() != 3 {
+		t.Fatalf("Load failed. Expected mesh %s with 3 vertices", mesh.Source)
+	}
+	m := rc.MaterialOverride
+	if m == nil || m.BaseColor != mesh.Material.BaseColor {
+		t.Errorf("Load failed. Expected base color %v, but got %v", mesh.Material.BaseColor, m)
+	} else if m.RoughnessFactor != 0.5 || m.EmissiveFactor != mesh.Material.EmissiveFactor {
+		t.Errorf("Load failed. Expected roughness 0.5 and emissive %v, but got %v and %v", mesh.Material.EmissiveFactor, m.RoughnessFactor, m.EmissiveFactor)
+	}
+	if rc.Mesh.Material != nil {
+		t.Errorf("Load failed. Expected the material of the shared mesh to be unchanged, but got %v", rc.Mesh.Material)
+	}
+}
+
+func TestSaveMeshWithoutSource(t *testing.T) {
+	w := ecs.NewWorld()
+	w.NewEntity(&RenderComponent{Mesh: &Mesh{}})
+
+	if err := ecs.Save(w, &bytes.Buffer{}); !errors.Is(err, ErrNoMeshSource) {
+		t.Errorf("Save failed. Expected %v, but got %v", ErrNoMeshSource, err)
+	}
+}
+
+func TestRestoreReleasedMeshes(t *testing.T) {
+	rs, _ := newSoftwareRenderer(t, 8, 8)
+	rs.ReleaseMeshes = true
+	w := ecs.NewWorld()
+	if err := w.AddSystems(rs); err != nil {
+		t.Fatal(err)
+	}
+
+	quad := newQuad(newSolidTexture(color.RGBA{255, 0, 0, 255}))
+	e := w.NewEntity(&RenderComponent{Mesh: quad, Scale: [3]float32{1, 1, 1}})
+
+	snap, err := w.Snapshot()
+	if err != nil {
+		t.Fatalf("Snapshot failed. Unexpected error for a mesh without source: %v", err)
+	}
+	if err := w.Restore(snap); err != nil {
+		t.Fatal(err)
+	}
+	rs.Update(0)
+
+	rc, ok := ecs.Get[*RenderComponent](w.Entity(e.ID()))
+	if !ok || rc.Mesh != quad {
+		t.Fatalf("Restore failed. Expected the restored entity to use the same mesh")
+	}
+	if quad.ID == 0 {
+		t.Errorf("Restore failed. Expected the mesh of the restored entity to stay loaded")
+	}
+	if _, exists := rs.Entities()[e.ID()]; !exists {
+		t.Errorf("Restore failed. Expected the restored entity within the RenderSystem")
+	}
+}
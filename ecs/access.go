@@ -0,0 +1,155 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// ErrUndeclaredWrite is returned by [World.Update] when access checking is enabled
+// and a system modified a component it did not declare as written
+var ErrUndeclaredWrite = errors.New("ecs: undeclared component write")
+
+// Access describes the names of the components a [System] reads and writes during its Update
+type Access struct {
+	Reads  []string
+	Writes []string
+}
+
+// Accessor is implemented by a [System] that declares which components it accesses.
+// Systems within the same [Stage] whose access does not conflict run in parallel.
+// A System that does not implement Accessor, such as one that has to run on the main thread, always runs on its own.
+type Accessor interface {
+	// Access returns the components the system reads and writes
+	Access() Access
+}
+
+// writes returns true if the component is declared as written
+func (a Access) writes(name string) bool {
+	for _, n := range a.Writes {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// conflicts returns true if either Access writes a component the other one reads or writes
+func (a Access) conflicts(b Access) bool {
+	for _, n := range a.Writes {
+		if b.writes(n) || contains(b.Reads, n) {
+			return true
+		}
+	}
+	for _, n := range b.Writes {
+		if contains(a.Reads, n) {
+			return true
+		}
+	}
+	return false
+}
+
+// contains returns true if the name exists within names
+func contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// dependsOn returns true if either system declares it has to run before or after the other
+func dependsOn(a, b System) bool {
+	if d, ok := a.(Dependent); ok && (contains(d.Before(), b.Name()) || contains(d.After(), b.Name())) {
+		return true
+	}
+	if d, ok := b.(Dependent); ok && (contains(d.Before(), a.Name()) || contains(d.After(), a.Name())) {
+		return true
+	}
+	return false
+}
+
+// batchSystems groups consecutive systems of the sorted order that can run in parallel.
+// A batch only contains systems of the same stage that declare non-conflicting access and do not depend on each other.
+func batchSystems(order []System) [][]System {
+	var batches [][]System
+	var current []System
+	for _, s := range order {
+		if current != nil && canJoin(current, s) {
+			current = append(current, s)
+			continue
+		}
+		if current != nil {
+			batches = append(batches, current)
+		}
+		current = []System{s}
+	}
+	if current != nil {
+		batches = append(batches, current)
+	}
+	return batches
+}
+
+// canJoin returns true if the system can run in parallel with every system of the batch
+func canJoin(batch []System, s System) bool {
+	a, ok := s.(Accessor)
+	if !ok {
+		return false
+	}
+	for _, other := range batch {
+		b, ok := other.(Accessor)
+		if !ok || stageOf(s) != stageOf(other) || dependsOn(s, other) || a.Access().conflicts(b.Access()) {
+			return false
+		}
+	}
+	return true
+}
+
+// componentValue returns a copy of the value a component points to, so it can be compared later on
+func componentValue(c Component) interface{} {
+	v := reflect.ValueOf(c)
+	if v.Kind() == reflect.Ptr && !v.IsNil() {
+		return v.Elem().Interface()
+	}
+	return c
+}
+
+// checkedUpdate updates the system and returns an error when it changed a component it did not declare as written.
+// Only the fields of a component are compared, changes made through pointers a component holds are not detected.
+func checkedUpdate(s System, dt float64) error {
+	a, ok := s.(Accessor)
+	if !ok {
+		s.Update(dt)
+		return nil
+	}
+	access := a.Access()
+
+	type key struct {
+		e    *Entity
+		name string
+	}
+	before := make(map[key]interface{})
+	for _, e := range s.Entities() {
+		for name, c := range e.componentMap() {
+			if !access.writes(name) {
+				before[key{e, name}] = componentValue(c)
+			}
+		}
+	}
+
+	s.Update(dt)
+
+	var violations []string
+	for k, v := range before {
+		c := k.e.Component(k.name)
+		if c != nil && !reflect.DeepEqual(v, componentValue(c)) {
+			violations = append(violations, fmt.Sprintf("%s on entity %v", k.name, k.e.ID()))
+		}
+	}
+	if len(violations) > 0 {
+		return fmt.Errorf("%w: %s wrote %s", ErrUndeclaredWrite, s.Name(), strings.Join(violations, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package ecs
+
+import "testing"
+
+type accessSystem struct {
+	BaseSystem
+	name   string
+	access Access
+	update func(*accessSystem)
+}
+
+func (a *accessSystem) Update(dt float64) {
+	if a.update != nil {
+		a.update(a)
+	}
+}
+func (a *accessSystem) Name() string   { return a.name }
+func (a *accessSystem) Access() Access { return a.access }
+func (a *accessSystem) Query() Query   { return Query{componentName} }
+
+type counterComponent struct {
+	Count int
+}
+
+func (c *counterComponent) Name() string {
+	return "CounterComponent"
+}
+
+func TestBatchSystems(t *testing.T) {
+	order := []System{
+		&accessSystem{BaseSystem: NewBaseSystem(), name: "A", access: Access{Reads: []string{"Position"}}},
+		&accessSystem{BaseSystem: NewBaseSystem(), name: "B", access: Access{Reads: []string{"Position"}, Writes: []string{"Velocity"}}},
+		&accessSystem{BaseSystem: NewBaseSystem(), name: "C", access: Access{Writes: []string{"Position"}}},
+		&TestSystem{NewBaseSystem()},
+		&accessSystem{BaseSystem: NewBaseSystem(), name: "D", access: Access{Writes: []string{"Health"}}},
+	}
+
+	batches := batchSystems(order)
+
+	expect := []int{2, 1, 1, 1}
+	if len(batches) != len(expect) {
+		t.Fatalf("batchSystems failed. Expected %d batches, but got %d", len(expect), len(batches))
+	}
+	for i, size := range expect {
+		if len(batches[i]) != size {
+			t.Errorf("batchSystems failed. Expected batch %d to hold %d systems, but got %d", i, size, len(batches[i]))
+		}
+	}
+}
+
+func TestParallelUpdate(t *testing.T) {
+	w := NewWorld()
+	counter := &counterComponent{}
+	w.NewEntity(&TestComponent{}, counter)
+
+	increment := func(a *accessSystem) {
+		for _, e := range a.Entities() {
+			e.Component("CounterComponent").(*counterComponent).Count++
+		}
+	}
+	w.AddSystems(
+		&accessSystem{BaseSystem: NewBaseSystem(), name: "A", access: Access{Reads: []string{componentName}}, update: func(*accessSystem) {}},
+		&accessSystem{BaseSystem: NewBaseSystem(), name: "B", access: Access{Writes: []string{"CounterComponent"}}, update: increment},
+	)
+
+	if err := w.Update(0); err != nil {
+		t.Fatalf("Update failed. Unexpected error: %v", err)
+	}
+	if counter.Count != 1 {
+		t.Errorf("Update failed. Expected count of 1, but got %d", counter.Count)
+	}
+}
+
+func TestAccessCheck(t *testing.T) {
+	w := NewWorld()
+	w.SetAccessCheck(true)
+	w.NewEntity(&TestComponent{}, &counterComponent{})
+
+	w.AddSystems(&accessSystem{
+		BaseSystem: NewBaseSystem(),
+		name:       "Sneaky",
+		access:     Access{Reads: []string{"CounterComponent"}},
+		update: func(a *accessSystem) {
+			for _, e := range a.Entities() {
+				e.Component("CounterComponent").(*counterComponent).Count++
+			}
+		},
+	})
+
+	if err := w.Update(0); err == nil {
+		t.Errorf("Update failed. Expected %v, but got nil", ErrUndeclaredWrite)
+	}
+}
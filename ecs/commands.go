@@ -0,0 +1,119 @@
+package ecs
+
+import "sync"
+
+// Commands records structural changes, such as spawning and destroying entities, while systems are updating.
+// The recorded changes are applied by [World.Update] once every [System] has been updated.
+// This makes it safe to change entities while other systems are iterating over their entities.
+type Commands struct {
+	m        *sync.Mutex
+	world    *World
+	commands []func()
+}
+
+// newCommands creates an empty command buffer for the World
+func newCommands(w *World) *Commands {
+	return &Commands{
+		m:     &sync.Mutex{},
+		world: w,
+	}
+}
+
+// record adds a command to the buffer
+func (c *Commands) record(cmd func()) {
+	c.m.Lock()
+	c.commands = append(c.commands, cmd)
+	c.m.Unlock()
+}
+
+// Spawn creates a new [Entity] with the given components which is registered with the [World] when the commands are applied.
+// The returned Entity can be used in other commands
+func (c *Commands) Spawn(components ...Component) *Entity {
+	e := newEntity(c.world, components...)
+	c.record(func() {
+		c.world.AddEntities(e)
+	})
+	return e
+}
+
+// Destroy destroys the [Entity] and its children when the commands are applied, see [Entity.Destroy]
+func (c *Commands) Destroy(e *Entity) {
+	c.record(e.Destroy)
+}
+
+// AddComponent adds the [Component] to the [Entity] when the commands are applied
+func (c *Commands) AddComponent(e *Entity, comp Component) {
+	c.record(func() {
+		e.AddComponent(comp)
+	})
+}
+
+// RemoveComponent removes the [Component] from the [Entity] when the commands are applied
+func (c *Commands) RemoveComponent(e *Entity, comp Component) {
+	c.record(func() {
+		e.RemoveComponent(comp)
+	})
+}
+
+// apply runs the recorded commands in the order they were recorded and empties the buffer
+func (c *Commands) apply() {
+	c.m.Lock()
+	commands := c.commands
+	c.commands = nil
+	c.m.Unlock()
+
+	for _, cmd := range commands {
+		cmd()
+	}
+}
+
+// Commands returns the command buffer of the [System] within the World.
+// Providing nil returns the buffer for code that does not run within a [System].
+// The buffers of the systems are applied in the order the systems are updated, followed by the buffer that does not belong to a system
+func (w *World) Commands(s System) *Commands {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if s == nil {
+		if w.commands == nil {
+			w.commands = newCommands(w)
+		}
+		return w.commands
+	}
+
+	c, exists := w.systemCommands[s]
+	if !exists {
+		c = newCommands(w)
+		w.systemCommands[s] = c
+	}
+	return c
+}
+
+// ApplyCommands applies every recorded command, this is done automatically at the end of [World.Update].
+// The commands of the systems are applied in the order of the systems, so no commands are applied when the systems
+// could not be ordered, and the error of the schedule is returned like [World.Update] does
+func (w *World) ApplyCommands() error {
+	batches, err := w.schedule()
+	if err != nil {
+		return err
+	}
+
+	w.m.RLock()
+	var buffers []*Commands
+	for _, batch := range batches {
+		for _, s := range batch {
+			if c, exists := w.systemCommands[s]; exists {
+				buffers = append(buffers, c)
+			}
+		}
+	}
+	if w.commands != nil {
+		buffers = append(buffers, w.commands)
+	}
+	w.m.RUnlock()
+
+	for _, c := range buffers {
+		c.apply()
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package ecs
+
+import (
+	"errors"
+	"testing"
+)
+
+type spawnSystem struct {
+	BaseSystem
+	name string
+	run  func(s *spawnSystem)
+}
+
+func (s *spawnSystem) Update(dt float64) { s.run(s) }
+func (s *spawnSystem) Name() string      { return s.name }
+func (s *spawnSystem) Query() Query      { return Query{componentName} }
+
+func TestCommandsAppliedAfterUpdate(t *testing.T) {
+	w := NewWorld()
+	var seen int
+	spawner := &spawnSystem{BaseSystem: NewBaseSystem(), name: "Spawner"}
+	spawner.run = func(s *spawnSystem) {
+		seen = len(s.Entities())
+		w.Commands(s).Spawn(&TestComponent{})
+		if len(s.Entities()) != seen {
+			t.Errorf("Spawn failed. Expected entity to be registered after the update")
+		}
+	}
+	w.AddSystems(spawner)
+
+	w.Update(0)
+	if len(spawner.Entities()) != 1 {
+		t.Fatalf("Update failed. Expected 1 entity after applying commands, but got %d", len(spawner.Entities()))
+	}
+
+	w.Update(0)
+	if seen != 1 || len(spawner.Entities()) != 2 {
+		t.Errorf("Update failed. Expected 2 entities, but got %d", len(spawner.Entities()))
+	}
+}
+
+func TestCommandsOrder(t *testing.T) {
+	w := NewWorld()
+	var order []string
+	e := w.NewEntity()
+
+	first := &spawnSystem{BaseSystem: NewBaseSystem(), name: "First"}
+	second := &spawnSystem{BaseSystem: NewBaseSystem(), name: "Second"}
+	first.run = func(s *spawnSystem) {
+		w.Commands(s).AddComponent(e, &TestComponent{})
+		w.Commands(nil).record(func() { order = append(order, "world") })
+		w.Commands(s).record(func() { order = append(order, "first") })
+	}
+	second.run = func(s *spawnSystem) {
+		w.Commands(s).RemoveComponent(e, testComponent)
+		w.Commands(s).record(func() { order = append(order, "second") })
+	}
+	w.AddSystems(second, first)
+
+	w.Update(0)
+
+	expect := []string{"second", "first", "world"}
+	if !equalOrder(order, expect) {
+		t.Errorf("ApplyCommands failed. Expected order %v, but got %v", expect, order)
+	}
+	if !e.HasComponent(componentName) {
+		t.Errorf("ApplyCommands failed. Expected the component to be added after it was removed")
+	}
+}
+
+func TestCommandsDestroy(t *testing.T) {
+	w := NewWorld()
+	e := w.NewEntity(&TestComponent{})
+	w.Commands(nil).Destroy(e)
+
+	if e.World() != w {
+		t.Errorf("Destroy failed. Expected entity to be destroyed once commands are applied")
+	}
+
+	if err := w.ApplyCommands(); err != nil {
+		t.Fatalf("ApplyCommands failed. Unexpected error: %v", err)
+	}
+	if e.World() != nil {
+		t.Errorf("Destroy failed. Expected entity to be destroyed")
+	}
+}
+
+func TestApplyCommandsScheduleError(t *testing.T) {
+	var log []string
+	w := NewWorld()
+	w.AddSystems(&orderedSystem{name: "A", before: []string{"Missing"}, log: &log})
+	e := w.NewEntity(&TestComponent{})
+	w.Commands(nil).Destroy(e)
+
+	if err := w.ApplyCommands(); !errors.Is(err, ErrUnknownDependency) {
+		t.Errorf("ApplyCommands failed. Expected %v, but got %v", ErrUnknownDependency, err)
+	}
+}
@@ -1,41 +1,59 @@
 package ecs
 
 import (
+	"reflect"
 	"sync"
 	"sync/atomic"
 )
 
-var currID int32 = 0
-
 // Entity is an object that can hold multiple components
 // Components can be added/removed/accessed concurrently
+// The components are kept in the archetype storage of the [World] the Entity is registered with,
+// the Entity itself is a handle to its row within that storage
 type Entity struct {
-	id         int32
-	m          *sync.RWMutex
-	components map[string]Component
-	childs     []*Entity
-	parent     *Entity
+	id     EntityID
+	m      *sync.RWMutex
+	childs []*Entity
+	parent *Entity
+	origin *World
+	world  *World
+	store  *atomic.Pointer[storage]
+	arch   *archetype
+	row    int
 }
 
 //NewEntity creates a new Entity with the given components
+//The ID of the Entity is allocated by the [DefaultWorld], but the Entity is not registered with it
+//Until the Entity is registered with a [World], its components are kept in a storage shared by every unregistered Entity.
+//The ID and components of an Entity are only freed by [Entity.Destroy], so call Destroy once the Entity is no longer needed,
+//also after removing it from its World with [World.RemoveEntity]
 func NewEntity(components ...Component) *Entity {
-	newID := atomic.AddInt32(&currID, 1)
-	comp := make(map[string]Component)
-	for _, c := range components {
-		if c != nil {
-			comp[c.Name()] = c
-		}
-	}
+	return newEntity(DefaultWorld(), components...)
+}
 
-	return &Entity{
-		id:         newID,
-		m:          &sync.RWMutex{},
-		components: comp,
+// newEntity creates a new Entity with an ID from the ID space of the given [World]
+func newEntity(w *World, components ...Component) *Entity {
+	e := &Entity{
+		m:      &sync.RWMutex{},
+		origin: w,
+		store:  &atomic.Pointer[storage]{},
 	}
+	e.id = w.allocate(e)
+	e.insertDetached(components)
+	return e
+}
+
+// insertDetached stores the components of a new Entity that is not registered with any [World] yet
+func (e *Entity) insertDetached(components []Component) {
+	e.store.Store(detached)
+
+	detached.m.Lock()
+	defer detached.m.Unlock()
+	detached.insert(e, components)
 }
 
 //ID returns the ID of an Entity
-func (e *Entity) ID() int32 {
+func (e *Entity) ID() EntityID {
 	e.m.RLock()
 	val := e.id
 	e.m.RUnlock()
@@ -49,15 +67,51 @@ func (e *Entity) setParent(p *Entity) {
 	e.m.Unlock()
 }
 
+// setWorld registers the Entity with the [World]
+// When the ID of the Entity was allocated by another [World], a new ID is allocated and the old one is released
+func (e *Entity) setWorld(w *World) {
+	e.m.Lock()
+	if e.origin != w {
+		e.origin.release(e.id, e)
+		e.id = w.allocate(e)
+		e.origin = w
+	}
+	e.world = w
+	migrate(e, w.storage)
+	e.m.Unlock()
+}
+
+// unsetWorld unregisters the Entity from its [World] and moves its components out of the storage of the [World]
+func (e *Entity) unsetWorld() {
+	e.m.Lock()
+	e.world = nil
+	migrate(e, detached)
+	e.m.Unlock()
+}
+
+// World returns the [World] the Entity is registered with, or nil if it is not registered
+func (e *Entity) World() *World {
+	e.m.RLock()
+	val := e.world
+	e.m.RUnlock()
+	return val
+}
+
 // AddChild adds a child to the entity and sets the parent of the child to the current entity
+// If the entity is registered with a [World], the child is registered as well
 func (e *Entity) AddChild(c *Entity) {
 	e.m.Lock()
 	e.childs = append(e.childs, c)
 	e.m.Unlock()
 	c.setParent(e)
+
+	if w := e.World(); w != nil {
+		w.AddEntities(c)
+	}
 }
 
 // AddChildren adds multiple children to an entity and sets the parent of each child to the current Entity
+// If the entity is registered with a [World], the children are registered as well
 func (e *Entity) AddChildren(childs []*Entity) {
 	e.m.Lock()
 	e.childs = append(e.childs, childs...)
@@ -65,6 +119,66 @@ func (e *Entity) AddChildren(childs []*Entity) {
 	for _, c := range childs {
 		c.setParent(e)
 	}
+
+	if w := e.World(); w != nil {
+		w.AddEntities(childs...)
+	}
+}
+
+// RemoveChild removes the child from the entity and clears the parent of the child.
+// The child remains registered with the [World] of the entity
+func (e *Entity) RemoveChild(c *Entity) {
+	e.m.Lock()
+	for i, child := range e.childs {
+		if child == c {
+			e.childs = append(e.childs[:i:i], e.childs[i+1:]...)
+			break
+		}
+	}
+	e.m.Unlock()
+
+	if c.Parent() == e {
+		c.setParent(nil)
+	}
+}
+
+// SetParent moves the Entity from its current parent to the given parent.
+// Providing nil detaches the Entity from its parent
+func (e *Entity) SetParent(p *Entity) {
+	if old := e.Parent(); old != nil {
+		old.RemoveChild(e)
+	}
+
+	if p != nil {
+		p.AddChild(e)
+	}
+}
+
+// Destroy removes the Entity and all of its children from their [World], which removes them from every [System].
+// The Entity is detached from its parent and the remove hooks of the [World] are run for every destroyed Entity.
+// Afterwards the ID of the Entity is released, which makes [World.Alive] return false for it.
+// [EntityDestroyed] is published before the components of the Entity are removed
+func (e *Entity) Destroy() {
+	for _, c := range e.Children() {
+		c.Destroy()
+	}
+
+	if p := e.Parent(); p != nil {
+		p.RemoveChild(e)
+	}
+
+	if w := e.World(); w != nil {
+		w.RemoveEntity(e)
+		Publish(w.bus, EntityDestroyed{e})
+	}
+
+	s := e.writeStorage()
+	s.take(e)
+	s.m.Unlock()
+
+	e.m.RLock()
+	e.origin.release(e.id, e)
+	e.m.RUnlock()
 }
 
 // Children returns the children of an Entity
@@ -83,33 +197,81 @@ func (e *Entity) Parent() *Entity {
 	return val
 }
 
+// componentOfType returns the [Component] of the given type, or nil when the Entity does not hold one
+func (e *Entity) componentOfType(t reflect.Type) Component {
+	s := e.readStorage()
+	defer s.m.RUnlock()
+	if e.arch == nil {
+		return nil
+	}
+	col, exists := e.arch.byType[t]
+	if !exists {
+		return nil
+	}
+	return s.get(e, col)
+}
+
 //AddComponent adds a [Component] to the Entity
+//The [World] the Entity is registered with will update the systems it belongs to and publish [ComponentAdded]
 func (e *Entity) AddComponent(c Component) {
-	e.m.Lock()
-	e.components[c.Name()] = c
-	e.m.Unlock()
+	s := e.writeStorage()
+	s.set(e, c)
+	s.m.Unlock()
+
+	if w := e.World(); w != nil {
+		w.refresh(e)
+		Publish(w.bus, ComponentAdded{e, c})
+	}
 }
 
 //RemoveComponent removes the given [Component] from the Entity
+//The [World] the Entity is registered with will update the systems it belongs to and publish [ComponentRemoved]
 func (e *Entity) RemoveComponent(c Component) {
-	e.m.Lock()
-	delete(e.components, c.Name())
-	e.m.Unlock()
+	s := e.writeStorage()
+	s.unset(e, c.Name())
+	s.m.Unlock()
+
+	if w := e.World(); w != nil {
+		w.refresh(e)
+		Publish(w.bus, ComponentRemoved{e, c})
+	}
 }
 
 //Component returns a [Component] based on its name
 //This returns nil if a [Component] does not exist
 func (e *Entity) Component(name string) Component {
-	e.m.RLock()
-	val, _ := e.components[name]
-	e.m.RUnlock()
+	s := e.readStorage()
+	defer s.m.RUnlock()
+	if e.arch == nil {
+		return nil
+	}
+	col, exists := e.arch.byName[name]
+	if !exists {
+		return nil
+	}
+	return s.get(e, col)
+}
+
+// componentMap returns a copy of the components of the Entity, where its key is the name of the [Component]
+func (e *Entity) componentMap() map[string]Component {
+	s := e.readStorage()
+	components := s.components(e)
+	s.m.RUnlock()
+
+	val := make(map[string]Component, len(components))
+	for _, c := range components {
+		val[c.Name()] = c
+	}
 	return val
 }
 
 //HasComponent checks if a [Component] exists based on the given name
 func (e *Entity) HasComponent(name string) bool {
-	e.m.RLock()
-	_, exists := e.components[name]
-	e.m.RUnlock()
+	s := e.readStorage()
+	defer s.m.RUnlock()
+	if e.arch == nil {
+		return false
+	}
+	_, exists := e.arch.byName[name]
 	return exists
 }
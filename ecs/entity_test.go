@@ -30,16 +30,15 @@ func TestRemoveComponent(t *testing.T) {
 func TestID(t *testing.T) {
 	te := NewEntity()
 	te2 := NewEntity()
-	expect := currID
 
 	if te.ID() == te2.ID() {
 		t.Errorf("ID failed. Expected different ID's but received the same")
 	}
 
-	result := te2.ID()
+	result := DefaultWorld().Entity(te2.ID())
 
-	if result != expect {
-		t.Errorf("ID failed. Expected %v, but got %v", expect, result)
+	if result != te2 {
+		t.Errorf("ID failed. Expected %v, but got %v", te2, result)
 	}
 }
 
@@ -52,3 +51,54 @@ func TestComponentFunc(t *testing.T) {
 		t.Errorf("Component failed. Expect testComponent but got different component")
 	}
 }
+
+func TestDestroy(t *testing.T) {
+	w := NewWorld()
+	ts := &QueryTestSystem{NewBaseSystem()}
+	w.AddSystems(ts)
+
+	var removed int
+	w.OnRemove(func(e *Entity) {
+		removed++
+	})
+
+	root := w.NewEntity()
+	parent := NewEntity(&TestComponent{})
+	child := NewEntity(&TestComponent{})
+	parent.AddChild(child)
+	root.AddChild(parent)
+
+	parent.Destroy()
+
+	if len(ts.Entities()) != 0 {
+		t.Errorf("Destroy failed. Expected 0 entities in the system, but got %d", len(ts.Entities()))
+	}
+	if removed != 2 {
+		t.Errorf("Destroy failed. Expected 2 remove hooks to run, but got %d", removed)
+	}
+	if len(root.Children()) != 0 || parent.Parent() != nil {
+		t.Errorf("Destroy failed. Expected entity to be detached from its parent")
+	}
+	if parent.World() != nil || child.World() != nil {
+		t.Errorf("Destroy failed. Expected entities to be unregistered from the world")
+	}
+}
+
+func TestSetParent(t *testing.T) {
+	a, b, c := NewEntity(), NewEntity(), NewEntity()
+	a.AddChild(c)
+
+	c.SetParent(b)
+
+	if len(a.Children()) != 0 {
+		t.Errorf("SetParent failed. Expected old parent to have 0 children, but got %d", len(a.Children()))
+	}
+	if c.Parent() != b || len(b.Children()) != 1 {
+		t.Errorf("SetParent failed. Expected entity to be a child of its new parent")
+	}
+
+	c.SetParent(nil)
+	if c.Parent() != nil || len(b.Children()) != 0 {
+		t.Errorf("SetParent failed. Expected entity to be detached")
+	}
+}
@@ -0,0 +1,119 @@
+package ecs
+
+import (
+	"reflect"
+	"sync"
+)
+
+// EntitySpawned is published when an [Entity] is registered with a [World]
+type EntitySpawned struct {
+	Entity *Entity
+}
+
+// EntityDestroyed is published when an [Entity] is destroyed, before its components are removed
+type EntityDestroyed struct {
+	Entity *Entity
+}
+
+// ComponentAdded is published when a [Component] is added to an [Entity] that is registered with a [World]
+type ComponentAdded struct {
+	Entity    *Entity
+	Component Component
+}
+
+// ComponentRemoved is published when a [Component] is removed from an [Entity] that is registered with a [World]
+type ComponentRemoved struct {
+	Entity    *Entity
+	Component Component
+}
+
+// Bus delivers typed events to its subscribers, which allows systems to communicate without knowing each other.
+// Events are either delivered immediately using [Publish], or queued using [Enqueue] and delivered on [Bus.Flush].
+// Handlers of immediate events run on the goroutine that publishes the event, which can be any system running in parallel
+type Bus struct {
+	m        *sync.RWMutex
+	handlers map[reflect.Type][]*Subscription
+	queue    []func()
+}
+
+// Subscription is a handler subscribed to a single type of event
+type Subscription struct {
+	bus     *Bus
+	typ     reflect.Type
+	handler interface{}
+}
+
+// NewBus creates a new Bus without any subscribers
+func NewBus() *Bus {
+	return &Bus{
+		m:        &sync.RWMutex{},
+		handlers: make(map[reflect.Type][]*Subscription),
+	}
+}
+
+// Subscribe registers the handler to be called for every event of type E that is published on the [Bus]
+func Subscribe[E any](b *Bus, handler func(E)) *Subscription {
+	s := &Subscription{
+		bus:     b,
+		typ:     reflect.TypeOf((*E)(nil)).Elem(),
+		handler: handler,
+	}
+
+	b.m.Lock()
+	b.handlers[s.typ] = append(b.handlers[s.typ], s)
+	b.m.Unlock()
+	return s
+}
+
+// Unsubscribe stops the handler from receiving any more events
+func (s *Subscription) Unsubscribe() {
+	b := s.bus
+	b.m.Lock()
+	subs := b.handlers[s.typ]
+	remaining := make([]*Subscription, 0, len(subs))
+	for _, sub := range subs {
+		if sub != s {
+			remaining = append(remaining, sub)
+		}
+	}
+	b.handlers[s.typ] = remaining
+	b.m.Unlock()
+}
+
+// Publish delivers the event to every subscriber of type E immediately
+func Publish[E any](b *Bus, event E) {
+	b.m.RLock()
+	subs := b.handlers[reflect.TypeOf((*E)(nil)).Elem()]
+	b.m.RUnlock()
+
+	for _, s := range subs {
+		s.handler.(func(E))(event)
+	}
+}
+
+// Enqueue queues the event, which is delivered to every subscriber of type E on the next [Bus.Flush]
+func Enqueue[E any](b *Bus, event E) {
+	b.m.Lock()
+	b.queue = append(b.queue, func() {
+		Publish(b, event)
+	})
+	b.m.Unlock()
+}
+
+// Flush delivers every queued event in the order they were queued.
+// Events that are queued while flushing are delivered on the next Flush
+func (b *Bus) Flush() {
+	b.m.Lock()
+	queue := b.queue
+	b.queue = nil
+	b.m.Unlock()
+
+	for _, deliver := range queue {
+		deliver()
+	}
+}
+
+// Events returns the event [Bus] of the World. Queued events are delivered at the end of every [World.Update]
+func (w *World) Events() *Bus {
+	return w.bus
+}
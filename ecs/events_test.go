@@ -0,0 +1,104 @@
+package ecs
+
+import (
+	"slices"
+	"testing"
+)
+
+type testEvent struct {
+	value int
+}
+
+func TestPublish(t *testing.T) {
+	b := NewBus()
+	var got []int
+	sub := Subscribe(b, func(e testEvent) {
+		got = append(got, e.value)
+	})
+	Subscribe(b, func(e string) {
+		t.Errorf("Publish failed. Expected no string events, but got %q", e)
+	})
+
+	Publish(b, testEvent{1})
+	if len(got) != 1 || got[0] != 1 {
+		t.Errorf("Publish failed. Expected [1], but got %v", got)
+	}
+
+	sub.Unsubscribe()
+	Publish(b, testEvent{2})
+	if len(got) != 1 {
+		t.Errorf("Unsubscribe failed. Expected 1 event, but got %d", len(got))
+	}
+}
+
+func TestEnqueue(t *testing.T) {
+	b := NewBus()
+	var got []int
+	Subscribe(b, func(e testEvent) {
+		got = append(got, e.value)
+		if e.value == 1 {
+			Enqueue(b, testEvent{3})
+		}
+	})
+
+	Enqueue(b, testEvent{1})
+	Enqueue(b, testEvent{2})
+	if len(got) != 0 {
+		t.Errorf("Enqueue failed. Expected no events before Flush, but got %v", got)
+	}
+
+	b.Flush()
+	if !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("Flush failed. Expected %v, but got %v", []int{1, 2}, got)
+	}
+
+	b.Flush()
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("Flush failed. Expected %v, but got %v", []int{1, 2, 3}, got)
+	}
+}
+
+func TestWorldFlushesEvents(t *testing.T) {
+	w := NewWorld()
+	var delivered bool
+	Subscribe(w.Events(), func(testEvent) { delivered = true })
+
+	s := &spawnSystem{BaseSystem: NewBaseSystem(), name: "Publisher"}
+	s.run = func(s *spawnSystem) {
+		Enqueue(w.Events(), testEvent{})
+		if delivered {
+			t.Errorf("Update failed. Expected queued event to be delivered after the systems are updated")
+		}
+	}
+	w.AddSystems(s)
+
+	w.Update(0)
+	if !delivered {
+		t.Errorf("Update failed. Expected queued event to be delivered")
+	}
+}
+
+func TestBuiltinEvents(t *testing.T) {
+	w := NewWorld()
+	var events []string
+	Subscribe(w.Events(), func(EntitySpawned) { events = append(events, "spawned") })
+	Subscribe(w.Events(), func(EntityDestroyed) { events = append(events, "destroyed") })
+	Subscribe(w.Events(), func(ComponentAdded) { events = append(events, "added") })
+	Subscribe(w.Events(), func(e ComponentRemoved) {
+		if e.Component.Name() != componentName {
+			t.Errorf("ComponentRemoved failed. Expected %s, but got %s", componentName, e.Component.Name())
+		}
+		events = append(events, "removed")
+	})
+
+	e := w.NewEntity()
+	w.AddEntities(e)
+	e.AddComponent(&TestComponent{})
+	e.RemoveComponent(&TestComponent{})
+	e.Destroy()
+
+	expect := []string{"spawned", "added", "removed", "destroyed"}
+	if !equalOrder(events, expect) {
+		t.Errorf("Events failed. Expected %v, but got %v", expect, events)
+	}
+}
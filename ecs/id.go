@@ -0,0 +1,109 @@
+package ecs
+
+import "fmt"
+
+// EntityID identifies an [Entity] within the ID space of a [World].
+// It is made up of an index, which is reused once an Entity is destroyed,
+// and a generation, which is increased every time the index is reused.
+// This makes it possible to tell a stale ID from the ID of a live Entity, see [World.Alive]
+type EntityID uint64
+
+// newEntityID creates an EntityID from an index and generation
+func newEntityID(index, generation uint32) EntityID {
+	return EntityID(uint64(generation)<<32 | uint64(index))
+}
+
+// Index returns the index of the ID, which is reused after the Entity is destroyed
+func (id EntityID) Index() uint32 {
+	return uint32(id)
+}
+
+// Generation returns the generation of the ID, which is increased every time the index is reused
+func (id EntityID) Generation() uint32 {
+	return uint32(id >> 32)
+}
+
+// String returns the ID as index:generation
+func (id EntityID) String() string {
+	return fmt.Sprintf("%d:%d", id.Index(), id.Generation())
+}
+
+// slot holds the Entity that currently uses an index of the ID space of a World
+type slot struct {
+	generation uint32
+	entity     *Entity
+}
+
+// allocate returns a new ID for the Entity, reusing the index of a destroyed Entity when possible
+func (w *World) allocate(e *Entity) EntityID {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if n := len(w.free); n > 0 {
+		index := w.free[n-1]
+		w.free = w.free[:n-1]
+		w.slots[index].entity = e
+		return newEntityID(index, w.slots[index].generation)
+	}
+
+	w.slots = append(w.slots, slot{generation: 1, entity: e})
+	return newEntityID(uint32(len(w.slots)-1), 1)
+}
+
+// claim assigns the exact ID to the Entity, i.e. when restoring a saved World.
+// Unused indices below the index of the ID become available for new entities
+func (w *World) claim(e *Entity, id EntityID) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	index := id.Index()
+	for uint32(len(w.slots)) <= index {
+		w.free = append(w.free, uint32(len(w.slots)))
+		w.slots = append(w.slots, slot{generation: 1})
+	}
+	if w.slots[index].entity != nil {
+		return fmt.Errorf("%w: %s", ErrDuplicateID, id)
+	}
+
+	for i, free := range w.free {
+		if free == index {
+			w.free = append(w.free[:i], w.free[i+1:]...)
+			break
+		}
+	}
+	w.slots[index] = slot{generation: id.Generation(), entity: e}
+	return nil
+}
+
+// release frees the index of the ID so it can be reused, every existing copy of the ID becomes stale.
+// Nothing is released when the index is used by another Entity, i.e. by an Entity restored with the same ID
+func (w *World) release(id EntityID, e *Entity) {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	index := id.Index()
+	if int(index) >= len(w.slots) || w.slots[index].generation != id.Generation() || w.slots[index].entity != e {
+		return
+	}
+	w.slots[index].generation++
+	w.slots[index].entity = nil
+	w.free = append(w.free, index)
+}
+
+// Alive returns true if the ID belongs to an [Entity] of this World that has not been destroyed
+func (w *World) Alive(id EntityID) bool {
+	return w.Entity(id) != nil
+}
+
+// Entity returns the [Entity] with the given ID, or nil when the ID is stale or unknown.
+// The Entity does not have to be registered with the World, it only has to be allocated from its ID space
+func (w *World) Entity(id EntityID) *Entity {
+	w.m.RLock()
+	defer w.m.RUnlock()
+
+	index := id.Index()
+	if int(index) >= len(w.slots) || w.slots[index].generation != id.Generation() {
+		return nil
+	}
+	return w.slots[index].entity
+}
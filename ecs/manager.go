@@ -1,57 +1,351 @@
 package ecs
 
 import (
+	"errors"
+	"fmt"
+	"slices"
 	"sync"
+
+	"github.com/luukdegram/rebound/profile"
 )
 
-var manager *Manager
+//ErrUnknownSystem is returned when a [System] could not be found by its name
+var ErrUnknownSystem = errors.New("ecs: unknown system")
 
-//Manager controls the [System]'s and updates each one of them, based on their priority
-//[System]'s can be added/read/removed concurrently
-type Manager struct {
-	m       *sync.RWMutex
-	systems []System
-}
+//Manager is the former name of [World], kept so existing code continues to work
+type Manager = World
 
-//GetManager returns a [Manager].
+//GetManager returns the default [World].
 func GetManager() *Manager {
-	if manager == nil {
-		manager = &Manager{
-			m:       &sync.RWMutex{},
-			systems: []System{},
+	return DefaultWorld()
+}
+
+//Update handles the update of each [System] in the order of their stage and dependencies.
+//Disabled systems are skipped, as are systems outside of [StageRender] while the world is paused.
+//Systems that implement [Accessor] and do not conflict with each other are updated in parallel.
+//Once every [System] is updated, the recorded [Commands] are applied and the queued events are delivered.
+//The time spent in each [System] is recorded by the default [profile.Profiler] when it is enabled.
+//Returns an error if the systems could not be sorted, see [World.Sort],
+//or if access checking is enabled and a [System] wrote a component it did not declare, see [World.SetAccessCheck]
+func (w *World) Update(dt float64) error {
+	return w.update("World.Update", dt, 1, func(Stage) bool { return true })
+}
+
+//FixedUpdate updates every [System] outside of [StageRender] with a fixed time step, like [World.Update].
+//It is meant to be called zero or more times per frame, followed by a single [World.RenderUpdate]
+func (w *World) FixedUpdate(dt float64) error {
+	return w.update("World.FixedUpdate", dt, 1, func(s Stage) bool { return s != StageRender })
+}
+
+//RenderUpdate updates every [System] in [StageRender], like [World.Update].
+//Alpha is the fraction of the fixed time step that has passed since the last [World.FixedUpdate],
+//which is passed on to every [Interpolator] before it is updated
+func (w *World) RenderUpdate(dt float64, alpha float64) error {
+	return w.update("World.RenderUpdate", dt, alpha, func(s Stage) bool { return s == StageRender })
+}
+
+// update updates the systems whose stage is included, see [World.Update].
+// The name is used to record the update in the default [profile.Profiler]
+func (w *World) update(name string, dt float64, alpha float64, include func(Stage) bool) error {
+	span := profile.Default().Begin(name, "world")
+	defer span.End()
+
+	batches, err := w.schedule()
+	if err != nil {
+		return err
+	}
+
+	w.m.RLock()
+	check := w.checkAccess
+	batches = w.active(batches, include)
+	w.m.RUnlock()
+
+	for _, batch := range batches {
+		for _, s := range batch {
+			if i, ok := s.(Interpolator); ok {
+				i.Interpolate(alpha)
+			}
+		}
+	}
+
+	if check {
+		for _, batch := range batches {
+			for _, s := range batch {
+				span := profile.Default().Begin(s.Name(), "system")
+				err := checkedUpdate(s, dt)
+				span.End()
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return w.finishUpdate()
+	}
+
+	for _, batch := range batches {
+		if len(batch) == 1 {
+			updateSystem(batch[0], dt)
+			continue
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(len(batch))
+		for _, s := range batch {
+			go func(s System) {
+				updateSystem(s, dt)
+				wg.Done()
+			}(s)
 		}
+		wg.Wait()
 	}
 
-	return manager
+	return w.finishUpdate()
 }
 
-//Update handles the update of each [System] based on their priority
-func (m *Manager) Update(dt float64) {
-	for _, s := range m.systems {
-		s.Update(dt)
+// updateSystem updates the system, recording the time it takes in the default [profile.Profiler]
+func updateSystem(s System, dt float64) {
+	span := profile.Default().Begin(s.Name(), "system")
+	s.Update(dt)
+	span.End()
+}
+
+// finishUpdate applies the recorded commands and delivers the queued events once every system is updated
+func (w *World) finishUpdate() error {
+	span := profile.Default().Begin("World.ApplyCommands", "world")
+	err := w.ApplyCommands()
+	span.End()
+
+	span = profile.Default().Begin("World.Events", "world")
+	w.bus.Flush()
+	span.End()
+	return err
+}
+
+// active filters the systems that should not be updated, or whose stage is not included, out of the batches.
+// The caller must hold the lock of the World
+func (w *World) active(batches [][]System, include func(Stage) bool) [][]System {
+	val := make([][]System, 0, len(batches))
+	for _, batch := range batches {
+		var filtered []System
+		for _, s := range batch {
+			stage := stageOf(s)
+			if w.disabled[s.Name()] || (w.paused && stage != StageRender) || !include(stage) {
+				continue
+			}
+			filtered = append(filtered, s)
+		}
+		if len(filtered) > 0 {
+			val = append(val, filtered)
+		}
 	}
+	return val
 }
 
-//AddSystems adds one or more [System]s to the manager
-func (m *Manager) AddSystems(systems ...System) {
-	m.m.Lock()
+//SetAccessCheck enables or disables access checking, which is meant to be used in tests.
+//When enabled, systems are updated one after another and [World.Update] returns [ErrUndeclaredWrite]
+//when a [System] that implements [Accessor] changed a component it did not declare in its Writes
+func (w *World) SetAccessCheck(enabled bool) {
+	w.m.Lock()
+	w.checkAccess = enabled
+	w.m.Unlock()
+}
+
+//Sort orders the [System]s by their [Stage] and [Dependent] constraints.
+//Returns an error when the dependencies contain a cycle or reference a [System] that does not exist.
+//Sort is called by [World.Update] whenever systems were added, so calling it is only needed to validate the systems early
+func (w *World) Sort() error {
+	_, err := w.schedule()
+	return err
+}
+
+// schedule returns the sorted systems grouped in batches that can run in parallel,
+// sorting them again if the systems changed
+func (w *World) schedule() ([][]System, error) {
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.batches == nil {
+		order, err := sortSystems(w.systems)
+		if err != nil {
+			return nil, err
+		}
+		w.batches = batchSystems(order)
+	}
+	return w.batches, nil
+}
+
+//AddSystems adds one or more [System]s to the world.
+//A [System] that implements [Initializer] is initialized before it is added, the first error is returned
+//and prevents that [System] and the ones after it from being added.
+//A [System] that implements [Querier] receives every registered [Entity] that matches its [Query]
+func (w *World) AddSystems(systems ...System) error {
 	for _, s := range systems {
-		m.systems = append(m.systems, s)
+		if i, ok := s.(Initializer); ok {
+			if err := i.Init(w); err != nil {
+				return err
+			}
+		}
+
+		w.m.Lock()
+		w.batches = nil
+		w.systems = append(w.systems, s)
+		entities := make([]*Entity, 0, len(w.entities))
+		for _, e := range w.entities {
+			entities = append(entities, e)
+		}
+		w.m.Unlock()
+
+		if q, ok := s.(Querier); ok {
+			query := q.Query()
+			for _, e := range entities {
+				if query.Matches(e) {
+					addToSystem(s, e)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+//RemoveSystem removes every [System] with the given name from the world and shuts them down.
+//The entities of the removed systems remain registered with the world
+func (w *World) RemoveSystem(name string) error {
+	w.m.Lock()
+	var removed []System
+	remaining := make([]System, 0, len(w.systems))
+	for _, s := range w.systems {
+		if s.Name() == name {
+			removed = append(removed, s)
+			delete(w.systemCommands, s)
+			continue
+		}
+		remaining = append(remaining, s)
+	}
+	w.systems = remaining
+	w.batches = nil
+	delete(w.disabled, name)
+	w.m.Unlock()
+
+	if len(removed) == 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownSystem, name)
+	}
+
+	for _, s := range removed {
+		if sd, ok := s.(Shutdowner); ok {
+			sd.Shutdown()
+		}
+	}
+	return nil
+}
+
+//System returns the first [System] with the given name, or nil if the world does not contain one
+func (w *World) System(name string) System {
+	for _, s := range w.Systems() {
+		if s.Name() == name {
+			return s
+		}
+	}
+	return nil
+}
+
+//Enable makes sure the [System] with the given name is updated again after it was disabled
+func (w *World) Enable(name string) error {
+	return w.setEnabled(name, true)
+}
+
+//Disable stops the [System] with the given name from being updated.
+//A disabled [System] still receives the entities that match its [Query]
+func (w *World) Disable(name string) error {
+	return w.setEnabled(name, false)
+}
+
+// setEnabled enables or disables every system with the given name
+func (w *World) setEnabled(name string, enabled bool) error {
+	if w.System(name) == nil {
+		return fmt.Errorf("%w: %s", ErrUnknownSystem, name)
+	}
+
+	w.m.Lock()
+	if enabled {
+		delete(w.disabled, name)
+	} else {
+		w.disabled[name] = true
+	}
+	w.m.Unlock()
+	return nil
+}
+
+//Enabled returns false if the [System] with the given name has been disabled
+func (w *World) Enabled(name string) bool {
+	w.m.RLock()
+	val := !w.disabled[name]
+	w.m.RUnlock()
+	return val
+}
+
+//Pause stops every [System] outside of [StageRender] from being updated, i.e. while a menu is opened
+func (w *World) Pause() {
+	w.m.Lock()
+	w.paused = true
+	w.m.Unlock()
+}
+
+//Resume continues updating the systems after the world was paused
+func (w *World) Resume() {
+	w.m.Lock()
+	w.paused = false
+	w.m.Unlock()
+}
+
+//Paused returns true if the world is paused
+func (w *World) Paused() bool {
+	w.m.RLock()
+	val := w.paused
+	w.m.RUnlock()
+	return val
+}
+
+//Shutdown shuts down every [System] that implements [Shutdowner], in the reverse order in which they were added
+func (w *World) Shutdown() {
+	systems := w.Systems()
+	for i := len(systems) - 1; i >= 0; i-- {
+		if sd, ok := systems[i].(Shutdowner); ok {
+			sd.Shutdown()
+		}
+	}
+}
+
+// addToSystem adds the entity to the system and notifies the system if it implements [EntityAddedHandler]
+func addToSystem(s System, e *Entity) {
+	s.AddEntities(e)
+	if h, ok := s.(EntityAddedHandler); ok {
+		h.OnEntityAdded(e)
+	}
+}
+
+// removeFromSystem removes the entity from the system and notifies the system if it implements [EntityRemovedHandler]
+func removeFromSystem(s System, e *Entity) {
+	s.RemoveEntity(e)
+	if h, ok := s.(EntityRemovedHandler); ok {
+		h.OnEntityRemoved(e)
 	}
-	m.m.Unlock()
 }
 
-//Systems returns a list of the [System]s the manager contains
-func (m *Manager) Systems() []System {
-	m.m.RLock()
-	val := m.systems
-	m.m.RUnlock()
+//Systems returns a copy of the list of the [System]s the world contains, in the order they were added.
+//Reorder systems using [Dependent] or [Stager], changing the returned list does not affect the world
+func (w *World) Systems() []System {
+	w.m.RLock()
+	val := slices.Clone(w.systems)
+	w.m.RUnlock()
 	return val
 }
 
-//Swap changes the priority of 2 [System]s by swapping them based on index in the list
-func (m *Manager) Swap(i, j int) {
-	m.m.Lock()
-	m.systems[i], m.systems[j] = m.systems[j], m.systems[i]
-	m.m.Unlock()
+//Swap changes the priority of 2 [System]s by swapping them based on index in the list.
+//The index order is only used between systems of the same [Stage] that have no dependency on each other.
+//
+//Deprecated: Implement [Dependent] or [Stager] to order systems instead.
+func (w *World) Swap(i, j int) {
+	w.m.Lock()
+	w.systems[i], w.systems[j] = w.systems[j], w.systems[i]
+	w.batches = nil
+	w.m.Unlock()
 }
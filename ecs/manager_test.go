@@ -1,6 +1,7 @@
 package ecs
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -18,3 +19,183 @@ func TestAddSystem(t *testing.T) {
 		t.Errorf("AddSystem failed. Expected %d, but got %d", expect, result)
 	}
 }
+
+type QueryTestSystem struct {
+	BaseSystem
+}
+
+func (t *QueryTestSystem) Update(dt float64) {}
+func (t *QueryTestSystem) Name() string {
+	return "QueryTestSystem"
+}
+func (t *QueryTestSystem) Query() Query {
+	return Query{componentName}
+}
+
+func TestQueryMembership(t *testing.T) {
+	w := NewWorld()
+	ts := &QueryTestSystem{NewBaseSystem()}
+	w.AddSystems(ts)
+
+	e := w.NewEntity()
+	if len(ts.Entities()) != 0 {
+		t.Errorf("Query failed. Expected no entities, but got %d", len(ts.Entities()))
+	}
+
+	e.AddComponent(testComponent)
+	if _, exists := ts.Entities()[e.ID()]; !exists {
+		t.Errorf("Query failed. Expected entity to be added after AddComponent")
+	}
+
+	e.RemoveComponent(testComponent)
+	if _, exists := ts.Entities()[e.ID()]; exists {
+		t.Errorf("Query failed. Expected entity to be removed after RemoveComponent")
+	}
+}
+
+func TestQueryChildrenAndBackfill(t *testing.T) {
+	w := NewWorld()
+	parent := NewEntity()
+	child := NewEntity(&TestComponent{})
+	parent.AddChild(child)
+	w.AddEntities(parent)
+
+	ts := &QueryTestSystem{NewBaseSystem()}
+	w.AddSystems(ts)
+	if _, exists := ts.Entities()[child.ID()]; !exists {
+		t.Errorf("AddSystems failed. Expected registered child to be added to the system")
+	}
+
+	late := NewEntity(&TestComponent{})
+	parent.AddChild(late)
+	if _, exists := ts.Entities()[late.ID()]; !exists {
+		t.Errorf("AddChild failed. Expected child of a registered entity to be added to the system")
+	}
+
+	w.RemoveEntity(parent)
+	if len(ts.Entities()) != 0 {
+		t.Errorf("RemoveEntity failed. Expected 0 entities, but got %d", len(ts.Entities()))
+	}
+}
+
+type lifecycleSystem struct {
+	QueryTestSystem
+	name     string
+	world    *World
+	updates  int
+	added    int
+	removed  int
+	shutdown bool
+}
+
+func (l *lifecycleSystem) Update(dt float64)         { l.updates++ }
+func (l *lifecycleSystem) Name() string              { return l.name }
+func (l *lifecycleSystem) Init(w *World) error       { l.world = w; return nil }
+func (l *lifecycleSystem) Shutdown()                 { l.shutdown = true }
+func (l *lifecycleSystem) OnEntityAdded(e *Entity)   { l.added++ }
+func (l *lifecycleSystem) OnEntityRemoved(e *Entity) { l.removed++ }
+
+type renderStageSystem struct {
+	lifecycleSystem
+}
+
+func (r *renderStageSystem) Stage() Stage { return StageRender }
+
+type interpolatedSystem struct {
+	renderStageSystem
+	alpha float64
+}
+
+func (i *interpolatedSystem) Interpolate(alpha float64) { i.alpha = alpha }
+
+func TestSystemLifecycle(t *testing.T) {
+	w := NewWorld()
+	ls := &lifecycleSystem{QueryTestSystem: QueryTestSystem{NewBaseSystem()}, name: "Lifecycle"}
+	if err := w.AddSystems(ls); err != nil {
+		t.Fatalf("AddSystems failed. Unexpected error: %v", err)
+	}
+
+	if ls.world != w {
+		t.Errorf("AddSystems failed. Expected Init to be called with the world")
+	}
+
+	e := w.NewEntity(&TestComponent{})
+	e.Destroy()
+	if ls.added != 1 || ls.removed != 1 {
+		t.Errorf("OnEntityAdded/OnEntityRemoved failed. Expected 1 and 1, but got %d and %d", ls.added, ls.removed)
+	}
+
+	if w.System("Lifecycle") != ls {
+		t.Errorf("System failed. Expected to find the system by name")
+	}
+
+	if err := w.RemoveSystem("Lifecycle"); err != nil || !ls.shutdown {
+		t.Errorf("RemoveSystem failed. Expected system to be shut down, got error %v", err)
+	}
+
+	if len(w.Systems()) != 0 || w.System("Lifecycle") != nil {
+		t.Errorf("RemoveSystem failed. Expected no systems")
+	}
+
+	if err := w.RemoveSystem("Lifecycle"); !errors.Is(err, ErrUnknownSystem) {
+		t.Errorf("RemoveSystem failed. Expected %v, but got %v", ErrUnknownSystem, err)
+	}
+}
+
+func TestSystemRuntimeControl(t *testing.T) {
+	w := NewWorld()
+	gameplay := &lifecycleSystem{QueryTestSystem: QueryTestSystem{NewBaseSystem()}, name: "Gameplay"}
+	render := &renderStageSystem{lifecycleSystem{QueryTestSystem: QueryTestSystem{NewBaseSystem()}, name: "Render"}}
+	w.AddSystems(gameplay, render)
+
+	w.Disable("Gameplay")
+	w.Update(0)
+	if gameplay.updates != 0 || render.updates != 1 || w.Enabled("Gameplay") {
+		t.Errorf("Disable failed. Expected only the render system to update")
+	}
+
+	w.Enable("Gameplay")
+	w.Pause()
+	w.Update(0)
+	if gameplay.updates != 0 || render.updates != 2 {
+		t.Errorf("Pause failed. Expected only the render system to update")
+	}
+
+	w.Resume()
+	w.Update(0)
+	if gameplay.updates != 1 || render.updates != 3 {
+		t.Errorf("Resume failed. Expected every system to update")
+	}
+
+	if err := w.Disable("Missing"); !errors.Is(err, ErrUnknownSystem) {
+		t.Errorf("Disable failed. Expected %v, but got %v", ErrUnknownSystem, err)
+	}
+
+	w.Shutdown()
+	if !gameplay.shutdown || !render.shutdown {
+		t.Errorf("Shutdown failed. Expected every system to be shut down")
+	}
+}
+
+func TestFixedAndRenderUpdate(t *testing.T) {
+	w := NewWorld()
+	gameplay := &lifecycleSystem{QueryTestSystem: QueryTestSystem{NewBaseSystem()}, name: "Gameplay"}
+	render := &interpolatedSystem{renderStageSystem: renderStageSystem{lifecycleSystem{QueryTestSystem: QueryTestSystem{NewBaseSystem()}, name: "Render"}}}
+	w.AddSystems(gameplay, render)
+
+	w.FixedUpdate(0)
+	w.FixedUpdate(0)
+	if gameplay.updates != 2 || render.updates != 0 {
+		t.Errorf("FixedUpdate failed. Expected only the gameplay system to update twice")
+	}
+
+	w.RenderUpdate(0, 0.25)
+	if gameplay.updates != 2 || render.updates != 1 || render.alpha != 0.25 {
+		t.Errorf("RenderUpdate failed. Expected only the render system to update with alpha 0.25, but got %v", render.alpha)
+	}
+
+	w.Update(0)
+	if gameplay.updates != 3 || render.updates != 2 || render.alpha != 1 {
+		t.Errorf("Update failed. Expected every system to update with alpha 1, but got %v", render.alpha)
+	}
+}
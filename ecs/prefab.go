@@ -0,0 +1,94 @@
+package ecs
+
+import "reflect"
+
+// Cloner is implemented by a [Component] that controls how it is copied, i.e. to deep copy its slices or maps
+type Cloner interface {
+	Clone() Component
+}
+
+// Override changes an instance of a [Prefab] before it is registered, i.e. to move it or change its material
+type Override func(root *Entity)
+
+// Prefab is a template of an [Entity] tree that can be instantiated many times.
+// Every instance receives its own entities and copies of the components
+type Prefab struct {
+	root *prefabNode
+}
+
+// prefabNode holds the components and children of a single Entity of the Prefab
+type prefabNode struct {
+	components []Component
+	children   []*prefabNode
+}
+
+// NewPrefab captures the [Entity] and its children as a Prefab.
+// The components are copied, so changes to the Entity afterwards do not affect the Prefab
+func NewPrefab(e *Entity) *Prefab {
+	return &Prefab{root: capture(e)}
+}
+
+// capture copies the components of the entity and its children
+func capture(e *Entity) *prefabNode {
+	n := &prefabNode{}
+	for _, c := range e.componentMap() {
+		n.components = append(n.components, CloneComponent(c))
+	}
+	for _, c := range e.Children() {
+		n.children = append(n.children, capture(c))
+	}
+	return n
+}
+
+// Instantiate creates a new [Entity] tree from the Prefab, with IDs allocated by the [DefaultWorld].
+// The overrides are applied to the root of the tree in order
+func (p *Prefab) Instantiate(overrides ...Override) *Entity {
+	return p.instantiate(DefaultWorld(), overrides)
+}
+
+// InstantiateIn creates a new [Entity] tree from the Prefab and registers it with the [World].
+// The overrides are applied to the root of the tree before it is registered
+func (p *Prefab) InstantiateIn(w *World, overrides ...Override) *Entity {
+	e := p.instantiate(w, overrides)
+	w.AddEntities(e)
+	return e
+}
+
+// instantiate creates the entity tree with IDs from the World and applies the overrides
+func (p *Prefab) instantiate(w *World, overrides []Override) *Entity {
+	e := p.root.build(w)
+	for _, o := range overrides {
+		o(e)
+	}
+	return e
+}
+
+// build creates an entity with copies of the components of the node and its children
+func (n *prefabNode) build(w *World) *Entity {
+	components := make([]Component, len(n.components))
+	for i, c := range n.components {
+		components[i] = CloneComponent(c)
+	}
+
+	e := newEntity(w, components...)
+	for _, c := range n.children {
+		e.AddChild(c.build(w))
+	}
+	return e
+}
+
+// CloneComponent returns a copy of the [Component] using its Clone method when it implements [Cloner].
+// Otherwise the value it points to is copied, which shares the pointers, slices and maps it holds, such as a mesh
+func CloneComponent(c Component) Component {
+	if cl, ok := c.(Cloner); ok {
+		return cl.Clone()
+	}
+
+	v := reflect.ValueOf(c)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return c
+	}
+	cp := reflect.New(v.Elem().Type())
+	cp.Elem().Set(v.Elem())
+	return cp.Interface().(Component)
+}
@@ -0,0 +1,53 @@
+package ecs
+
+import "testing"
+
+type tagsComponent struct {
+	Tags []string
+}
+
+func (t *tagsComponent) Name() string {
+	return "TagsComponent"
+}
+
+func (t *tagsComponent) Clone() Component {
+	return &tagsComponent{Tags: append([]string(nil), t.Tags...)}
+}
+
+func TestPrefabInstantiate(t *testing.T) {
+	root := NewEntity(&positionComponent{X: 1})
+	root.AddChild(NewEntity(&tagsComponent{Tags: []string{"a"}}, &velocityComponent{X: 2}))
+	prefab := NewPrefab(root)
+
+	root.AddComponent(&positionComponent{X: 5})
+
+	w := NewWorld()
+	a := prefab.InstantiateIn(w)
+	b := prefab.InstantiateIn(w, func(e *Entity) {
+		pos, _ := Get[*positionComponent](e)
+		pos.X = 10
+	})
+
+	if a.ID() == b.ID() || len(w.Entities()) != 4 {
+		t.Fatalf("Instantiate failed. Expected 4 entities with unique IDs, but got %d", len(w.Entities()))
+	}
+
+	posA, _ := Get[*positionComponent](a)
+	posB, _ := Get[*positionComponent](b)
+	if posA.X != 1 || posB.X != 10 {
+		t.Errorf("Instantiate failed. Expected positions 1 and 10, but got %v and %v", posA.X, posB.X)
+	}
+
+	tagsA, _ := Get[*tagsComponent](a.Children()[0])
+	tagsB, _ := Get[*tagsComponent](b.Children()[0])
+	tagsA.Tags[0] = "changed"
+	if tagsB.Tags[0] != "a" {
+		t.Errorf("Instantiate failed. Expected cloned tags to be independent, but got %v", tagsB.Tags)
+	}
+
+	velA, _ := Get[*velocityComponent](a.Children()[0])
+	velB, _ := Get[*velocityComponent](b.Children()[0])
+	if velA == velB || velA.X != 2 {
+		t.Errorf("Instantiate failed. Expected copied velocity components")
+	}
+}
@@ -0,0 +1,21 @@
+package ecs
+
+// Query describes the names of the components an [Entity] requires to be part of a [System]
+type Query []string
+
+// Querier is implemented by a [System] that wants the [World] to keep its entities up to date.
+// Registered entities are added to and removed from the [System] as they start or stop matching its [Query]
+type Querier interface {
+	//Query returns the [Query] an [Entity] must match to be part of the [System]
+	Query() Query
+}
+
+// Matches returns true if the [Entity] holds every component of the [Query]
+func (q Query) Matches(e *Entity) bool {
+	for _, name := range q {
+		if !e.HasComponent(name) {
+			return false
+		}
+	}
+	return true
+}
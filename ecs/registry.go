@@ -0,0 +1,81 @@
+package ecs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// ErrUnregisteredComponent is returned when a [Component] is serialized or deserialized without a registered [Codec]
+var ErrUnregisteredComponent = errors.New("ecs: unregistered component")
+
+// Codec converts a [Component] to and from its serialized form
+type Codec interface {
+	// Encode returns the serialized form of the component, which must be valid JSON
+	Encode(c Component) ([]byte, error)
+	// Decode creates a new component from its serialized form
+	Decode(data []byte) (Component, error)
+}
+
+// SnapshotCodec is a [Codec] that encodes components differently within a [Snapshot].
+// A Snapshot is restored within the same process, so it may reference data that only exists in memory
+type SnapshotCodec interface {
+	Codec
+	// EncodeSnapshot returns the serialized form of the component within a Snapshot, which must be valid JSON
+	EncodeSnapshot(c Component) ([]byte, error)
+}
+
+var (
+	codecs      = make(map[string]Codec)
+	codecsMutex sync.RWMutex
+)
+
+// RegisterComponent registers the [Codec] that is used to serialize every [Component] with the given name.
+// Registering a name again replaces its codec
+func RegisterComponent(name string, codec Codec) {
+	codecsMutex.Lock()
+	codecs[name] = codec
+	codecsMutex.Unlock()
+}
+
+// RegisterJSON registers a [Codec] for component type T that serializes its exported fields using encoding/json
+func RegisterJSON[T Component](name string) {
+	RegisterComponent(name, jsonCodec[T]{})
+}
+
+// codecOf returns the codec registered for the component name
+func codecOf(name string) (Codec, error) {
+	codecsMutex.RLock()
+	codec, exists := codecs[name]
+	codecsMutex.RUnlock()
+	if !exists {
+		return nil, fmt.Errorf("%w: %s", ErrUnregisteredComponent, name)
+	}
+	return codec, nil
+}
+
+// jsonCodec serializes components of type T using encoding/json
+type jsonCodec[T Component] struct{}
+
+func (jsonCodec[T]) Encode(c Component) ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func (jsonCodec[T]) Decode(data []byte) (Component, error) {
+	t := typeOf[T]()
+	if t.Kind() == reflect.Ptr {
+		v := reflect.New(t.Elem())
+		if err := json.Unmarshal(data, v.Interface()); err != nil {
+			return nil, err
+		}
+		return v.Interface().(Component), nil
+	}
+
+	v := reflect.New(t)
+	if err := json.Unmarshal(data, v.Interface()); err != nil {
+		return nil, err
+	}
+	return v.Elem().Interface().(Component), nil
+}
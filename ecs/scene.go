@@ -0,0 +1,145 @@
+package ecs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrDuplicateID is returned by [Load] when multiple entities share the same ID
+var ErrDuplicateID = errors.New("ecs: duplicate entity id")
+
+// sceneData is the serialized form of a World
+type sceneData struct {
+	Entities []entityData `json:"entities"`
+}
+
+// entityData is the serialized form of an Entity
+type entityData struct {
+	ID         EntityID                   `json:"id"`
+	Parent     *EntityID                  `json:"parent,omitempty"`
+	Components map[string]json.RawMessage `json:"components"`
+}
+
+// MarshalText returns the ID as index:generation
+func (id EntityID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText parses an ID in the index:generation format
+func (id *EntityID) UnmarshalText(text []byte) error {
+	var index, generation uint32
+	if _, err := fmt.Sscanf(string(text), "%d:%d", &index, &generation); err != nil {
+		return fmt.Errorf("ecs: invalid entity id %q: %w", text, err)
+	}
+	*id = newEntityID(index, generation)
+	return nil
+}
+
+// Save writes every [Entity] registered with the World, including its ID, parent and components, as JSON.
+// Every [Component] needs a registered [Codec], see [RegisterComponent]
+func Save(w *World, out io.Writer) error {
+	entities := w.Entities()
+	ids := make([]EntityID, 0, len(entities))
+	for id := range entities {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].Index() < ids[j].Index() })
+
+	scene := sceneData{Entities: make([]entityData, 0, len(ids))}
+	for _, id := range ids {
+		e := entities[id]
+		data := entityData{ID: id, Components: make(map[string]json.RawMessage)}
+		if p := e.Parent(); p != nil {
+			if _, registered := entities[p.ID()]; registered {
+				parent := p.ID()
+				data.Parent = &parent
+			}
+		}
+
+		for name, c := range e.componentMap() {
+			codec, err := codecOf(name)
+			if err != nil {
+				return err
+			}
+			raw, err := codec.Encode(c)
+			if err != nil {
+				return fmt.Errorf("ecs: encoding %s of entity %s: %w", name, id, err)
+			}
+			data.Components[name] = raw
+		}
+		scene.Entities = append(scene.Entities, data)
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(scene)
+}
+
+// Load creates a new [World] from the JSON written by [Save].
+// The entities keep their IDs and parents, every [Component] is created by its registered [Codec]
+func Load(in io.Reader) (*World, error) {
+	var scene sceneData
+	if err := json.NewDecoder(in).Decode(&scene); err != nil {
+		return nil, err
+	}
+
+	w := NewWorld()
+	entities := make(map[EntityID]*Entity, len(scene.Entities))
+	for _, data := range scene.Entities {
+		components := make([]Component, 0, len(data.Components))
+		for name, raw := range data.Components {
+			codec, err := codecOf(name)
+			if err != nil {
+				return nil, err
+			}
+			c, err := codec.Decode(raw)
+			if err != nil {
+				return nil, fmt.Errorf("ecs: decoding %s of entity %s: %w", name, data.ID, err)
+			}
+			components = append(components, c)
+		}
+
+		e, err := restoreEntity(w, data.ID, components...)
+		if err != nil {
+			return nil, err
+		}
+		entities[data.ID] = e
+	}
+
+	var roots []*Entity
+	for _, data := range scene.Entities {
+		e := entities[data.ID]
+		if data.Parent == nil {
+			roots = append(roots, e)
+			continue
+		}
+		p, exists := entities[*data.Parent]
+		if !exists {
+			return nil, fmt.Errorf("ecs: unknown parent %s of entity %s", *data.Parent, data.ID)
+		}
+		p.AddChild(e)
+	}
+
+	w.AddEntities(roots...)
+	return w, nil
+}
+
+// restoreEntity creates a new Entity with the exact ID within the ID space of the World
+func restoreEntity(w *World, id EntityID, components ...Component) (*Entity, error) {
+	e := &Entity{
+		m:      &sync.RWMutex{},
+		origin: w,
+		store:  &atomic.Pointer[storage]{},
+	}
+	if err := w.claim(e, id); err != nil {
+		return nil, err
+	}
+	e.id = id
+	e.insertDetached(components)
+	return e, nil
+}
@@ -0,0 +1,73 @@
+package ecs
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func init() {
+	RegisterJSON[*positionComponent]("PositionComponent")
+	RegisterJSON[*TestComponent](componentName)
+}
+
+func TestSaveLoad(t *testing.T) {
+	w := NewWorld()
+	destroyed := w.NewEntity()
+	root := newEntity(w, &positionComponent{X: 1, Y: 2})
+	child := newEntity(w, &TestComponent{})
+	root.AddChild(child)
+	w.AddEntities(root)
+	destroyed.Destroy()
+
+	var buf bytes.Buffer
+	if err := Save(w, &buf); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := Load(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(loaded.Entities()) != 2 {
+		t.Fatalf("Load failed. Expected 2 entities, but got %d", len(loaded.Entities()))
+	}
+
+	lr := loaded.Entity(root.ID())
+	lc := loaded.Entity(child.ID())
+	if lr == nil || lc == nil {
+		t.Fatalf("Load failed. Expected entities with IDs %s and %s", root.ID(), child.ID())
+	}
+	if lc.Parent() != lr {
+		t.Errorf("Load failed. Expected child to have parent %s", lr.ID())
+	}
+	if pos, ok := Get[*positionComponent](lr); !ok || pos.X != 1 || pos.Y != 2 {
+		t.Errorf("Load failed. Expected position {1 2}, but got %v", pos)
+	}
+	if !lc.HasComponent(componentName) {
+		t.Errorf("Load failed. Expected child to have %s", componentName)
+	}
+
+	// The index of the destroyed entity must be reused by new entities
+	if e := loaded.NewEntity(); e.ID().Index() != destroyed.ID().Index() {
+		t.Errorf("Load failed. Expected new entity to reuse index %d, but got %d", destroyed.ID().Index(), e.ID().Index())
+	}
+}
+
+func TestSaveUnregistered(t *testing.T) {
+	w := NewWorld()
+	w.NewEntity(&velocityComponent{})
+
+	if err := Save(w, &bytes.Buffer{}); !errors.Is(err, ErrUnregisteredComponent) {
+		t.Errorf("Save failed. Expected %v, but got %v", ErrUnregisteredComponent, err)
+	}
+}
+
+func TestLoadDuplicateID(t *testing.T) {
+	scene := `{"entities": [{"id": "0:1", "components": {}}, {"id": "0:1", "components": {}}]}`
+	if _, err := Load(strings.NewReader(scene)); !errors.Is(err, ErrDuplicateID) {
+		t.Errorf("Load failed. Expected %v, but got %v", ErrDuplicateID, err)
+	}
+}
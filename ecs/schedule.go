@@ -0,0 +1,159 @@
+package ecs
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Stage describes the phase of a frame in which a [System] runs.
+// Systems in an earlier stage always run before systems in a later stage.
+type Stage int
+
+const (
+	// StagePreUpdate runs before any gameplay logic, i.e. to handle input
+	StagePreUpdate Stage = iota
+	// StageUpdate is the default stage of a [System]
+	StageUpdate
+	// StagePostUpdate runs after the gameplay logic, i.e. to resolve transforms
+	StagePostUpdate
+	// StageRender runs last and is meant for systems that draw the frame
+	StageRender
+)
+
+var (
+	// ErrDependencyCycle is returned when systems depend on each other in a cycle
+	ErrDependencyCycle = errors.New("ecs: dependency cycle between systems")
+	// ErrUnknownDependency is returned when a system depends on a system that does not exist in the World
+	ErrUnknownDependency = errors.New("ecs: dependency on unknown system")
+	// ErrStageOrder is returned when a system depends on a system that runs in a conflicting stage
+	ErrStageOrder = errors.New("ecs: dependency conflicts with stage order")
+)
+
+// Stager is implemented by a [System] that runs in a different stage than [StageUpdate]
+type Stager interface {
+	// Stage returns the stage in which the system runs
+	Stage() Stage
+}
+
+// Dependent is implemented by a [System] that has to run before or after other systems.
+// Systems are referenced by their [System.Name]
+type Dependent interface {
+	// Before returns the names of the systems this system must run before
+	Before() []string
+	// After returns the names of the systems this system must run after
+	After() []string
+}
+
+// String returns the name of the stage
+func (s Stage) String() string {
+	switch s {
+	case StagePreUpdate:
+		return "PreUpdate"
+	case StageUpdate:
+		return "Update"
+	case StagePostUpdate:
+		return "PostUpdate"
+	case StageRender:
+		return "Render"
+	}
+	return fmt.Sprintf("Stage(%d)", int(s))
+}
+
+// stageOf returns the stage of a system, [StageUpdate] when it does not implement [Stager]
+func stageOf(s System) Stage {
+	if st, ok := s.(Stager); ok {
+		return st.Stage()
+	}
+	return StageUpdate
+}
+
+// sortSystems orders the systems by stage and then by their dependencies.
+// Systems without constraints between them keep the order in which they were added.
+func sortSystems(systems []System) ([]System, error) {
+	byName := make(map[string][]int, len(systems))
+	for i, s := range systems {
+		byName[s.Name()] = append(byName[s.Name()], i)
+	}
+
+	// edges[i] holds the systems that have to run after system i
+	edges := make([][]int, len(systems))
+	inDegree := make([]int, len(systems))
+	addEdge := func(from, to int) error {
+		fs, ts := stageOf(systems[from]), stageOf(systems[to])
+		if fs > ts {
+			return fmt.Errorf("%w: %s (%v) must run before %s (%v)", ErrStageOrder, systems[from].Name(), fs, systems[to].Name(), ts)
+		}
+		if fs < ts {
+			// Already satisfied by the stage order
+			return nil
+		}
+		edges[from] = append(edges[from], to)
+		inDegree[to]++
+		return nil
+	}
+
+	for i, s := range systems {
+		d, ok := s.(Dependent)
+		if !ok {
+			continue
+		}
+
+		for _, name := range d.Before() {
+			targets, exists := byName[name]
+			if !exists {
+				return nil, fmt.Errorf("%w: %s runs before %s", ErrUnknownDependency, s.Name(), name)
+			}
+			for _, j := range targets {
+				if err := addEdge(i, j); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		for _, name := range d.After() {
+			targets, exists := byName[name]
+			if !exists {
+				return nil, fmt.Errorf("%w: %s runs after %s", ErrUnknownDependency, s.Name(), name)
+			}
+			for _, j := range targets {
+				if err := addEdge(j, i); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	// Repeatedly pick the earliest added system of the lowest stage that has no unmet dependencies
+	order := make([]System, 0, len(systems))
+	done := make([]bool, len(systems))
+	for len(order) < len(systems) {
+		next := -1
+		for i, s := range systems {
+			if done[i] || inDegree[i] > 0 {
+				continue
+			}
+			if next == -1 || stageOf(s) < stageOf(systems[next]) {
+				next = i
+			}
+		}
+
+		if next == -1 {
+			var names []string
+			for i, s := range systems {
+				if !done[i] {
+					names = append(names, s.Name())
+				}
+			}
+			return nil, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(names, ", "))
+		}
+
+		done[next] = true
+		order = append(order, systems[next])
+		for _, j := range edges[next] {
+			inDegree[j]--
+		}
+	}
+
+	return order, nil
+}
@@ -0,0 +1,115 @@
+package ecs
+
+import (
+	"errors"
+	"testing"
+)
+
+type orderedSystem struct {
+	BaseSystem
+	name   string
+	stage  Stage
+	before []string
+	after  []string
+	log    *[]string
+}
+
+func (o *orderedSystem) Update(dt float64) {
+	*o.log = append(*o.log, o.name)
+}
+func (o *orderedSystem) Name() string     { return o.name }
+func (o *orderedSystem) Stage() Stage     { return o.stage }
+func (o *orderedSystem) Before() []string { return o.before }
+func (o *orderedSystem) After() []string  { return o.after }
+
+func equalOrder(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestScheduleOrder(t *testing.T) {
+	var log []string
+	w := NewWorld()
+	w.AddSystems(
+		&orderedSystem{name: "Render", stage: StageRender, log: &log},
+		&orderedSystem{name: "Physics", stage: StageUpdate, after: []string{"Movement"}, log: &log},
+		&orderedSystem{name: "Movement", stage: StageUpdate, log: &log},
+		&orderedSystem{name: "Input", stage: StagePreUpdate, log: &log},
+		&orderedSystem{name: "Audio", stage: StageUpdate, before: []string{"Movement"}, log: &log},
+	)
+
+	if err := w.Update(0); err != nil {
+		t.Fatalf("Update failed. Unexpected error: %v", err)
+	}
+
+	expect := []string{"Input", "Audio", "Movement", "Physics", "Render"}
+	if !equalOrder(log, expect) {
+		t.Errorf("Update failed. Expected order %v, but got %v", expect, log)
+	}
+}
+
+func TestSystemsCopy(t *testing.T) {
+	var log []string
+	w := NewWorld()
+	w.AddSystems(&orderedSystem{name: "A", log: &log}, &orderedSystem{name: "B", log: &log})
+
+	systems := w.Systems()
+	systems[0], systems[1] = systems[1], systems[0]
+	w.Update(0)
+	if expect := []string{"A", "B"}; !equalOrder(log, expect) {
+		t.Errorf("Systems failed. Expected order %v after changing the returned list, but got %v", expect, log)
+	}
+
+	log = nil
+	w.Swap(0, 1)
+	w.Update(0)
+	if expect := []string{"B", "A"}; !equalOrder(log, expect) {
+		t.Errorf("Swap failed. Expected order %v, but got %v", expect, log)
+	}
+}
+
+func TestScheduleErrors(t *testing.T) {
+	var log []string
+	tests := []struct {
+		name    string
+		systems []System
+		err     error
+	}{
+		{
+			"cycle",
+			[]System{
+				&orderedSystem{name: "A", after: []string{"B"}, log: &log},
+				&orderedSystem{name: "B", after: []string{"A"}, log: &log},
+			},
+			ErrDependencyCycle,
+		},
+		{
+			"unknown",
+			[]System{&orderedSystem{name: "A", before: []string{"Missing"}, log: &log}},
+			ErrUnknownDependency,
+		},
+		{
+			"stage",
+			[]System{
+				&orderedSystem{name: "A", stage: StageRender, log: &log},
+				&orderedSystem{name: "B", stage: StagePreUpdate, after: []string{"A"}, log: &log},
+			},
+			ErrStageOrder,
+		},
+	}
+
+	for _, test := range tests {
+		w := NewWorld()
+		w.AddSystems(test.systems...)
+		if err := w.Sort(); !errors.Is(err, test.err) {
+			t.Errorf("Sort failed for %s. Expected %v, but got %v", test.name, test.err, err)
+		}
+	}
+}
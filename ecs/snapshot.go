@@ -0,0 +1,387 @@
+package ecs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"slices"
+	"sort"
+)
+
+// ErrInvalidSnapshot is returned when the binary form of a [Snapshot] could not be read
+var ErrInvalidSnapshot = errors.New("ecs: invalid snapshot")
+
+// Snapshot is a copy of every [Entity] registered with a [World], including its components and children,
+// and of the ID space of the World. The same state always results in the same Snapshot,
+// which makes it possible to compare the state of 2 worlds using [Snapshot.Hash] or [DiffSnapshots]
+type Snapshot struct {
+	generations []uint32
+	free        []uint32
+	entities    []entitySnapshot
+}
+
+// entitySnapshot is the state of a single Entity
+type entitySnapshot struct {
+	id         EntityID
+	children   []EntityID
+	components []componentSnapshot
+}
+
+// componentSnapshot is a component encoded by its registered Codec
+type componentSnapshot struct {
+	name string
+	data []byte
+}
+
+// Snapshot copies the state of the World, i.e. to restore it later using [World.Restore].
+// Every [Component] needs a registered [Codec], see [RegisterComponent], which is used as [SnapshotCodec] when implemented
+func (w *World) Snapshot() (*Snapshot, error) {
+	w.m.RLock()
+	s := &Snapshot{
+		generations: make([]uint32, len(w.slots)),
+		free:        append([]uint32(nil), w.free...),
+	}
+	for i, slot := range w.slots {
+		s.generations[i] = slot.generation
+	}
+	w.m.RUnlock()
+
+	entities := w.Entities()
+	s.entities = make([]entitySnapshot, 0, len(entities))
+	for id, e := range entities {
+		es := entitySnapshot{id: id}
+		for _, c := range e.Children() {
+			es.children = append(es.children, c.ID())
+		}
+
+		for name, c := range e.componentMap() {
+			codec, err := codecOf(name)
+			if err != nil {
+				return nil, err
+			}
+			var raw []byte
+			if sc, ok := codec.(SnapshotCodec); ok {
+				raw, err = sc.EncodeSnapshot(c)
+			} else {
+				raw, err = codec.Encode(c)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("ecs: encoding %s of entity %s: %w", name, id, err)
+			}
+			var compact bytes.Buffer
+			if err := json.Compact(&compact, raw); err != nil {
+				return nil, fmt.Errorf("ecs: encoding %s of entity %s: %w", name, id, err)
+			}
+			es.components = append(es.components, componentSnapshot{name, compact.Bytes()})
+		}
+		sort.Slice(es.components, func(i, j int) bool { return es.components[i].name < es.components[j].name })
+		s.entities = append(s.entities, es)
+	}
+	sort.Slice(s.entities, func(i, j int) bool { return s.entities[i].id.Index() < s.entities[j].id.Index() })
+	return s, nil
+}
+
+// Restore replaces every [Entity] of the World with the entities of the Snapshot and restores the ID space,
+// so new entities receive the same IDs as they did after the Snapshot was taken.
+// The current entities are removed from the World, including its systems, and new entities are created in their place.
+// The IDs and components of the removed entities are freed, so they do not need to be destroyed.
+// Existing references to entities should be looked up again by their ID, see [World.Entity].
+// The current entities are removed like [World.RemoveEntity] does, so systems and the remove hooks of the World see them being removed.
+// Systems receive the restored entities that match their [Query], no [EntitySpawned] or [EntityDestroyed] events are published.
+// Handles to the current entities become stale, destroying them does not affect the restored entities.
+// If a [Component] could not be decoded, or the Snapshot holds duplicate IDs, the World is left untouched
+func (w *World) Restore(s *Snapshot) error {
+	components := make([][]Component, len(s.entities))
+	seen := make(map[uint32]bool, len(s.entities))
+	for i, es := range s.entities {
+		if seen[es.id.Index()] {
+			return fmt.Errorf("%w: %s", ErrDuplicateID, es.id)
+		}
+		seen[es.id.Index()] = true
+
+		for _, cs := range es.components {
+			codec, err := codecOf(cs.name)
+			if err != nil {
+				return err
+			}
+			c, err := codec.Decode(cs.data)
+			if err != nil {
+				return fmt.Errorf("ecs: decoding %s of entity %s: %w", cs.name, es.id, err)
+			}
+			components[i] = append(components[i], c)
+		}
+	}
+
+	for _, e := range w.Entities() {
+		w.RemoveEntity(e)
+		st := e.writeStorage()
+		st.take(e)
+		st.m.Unlock()
+	}
+
+	w.m.Lock()
+	w.slots = make([]slot, len(s.generations))
+	for i, generation := range s.generations {
+		w.slots[i].generation = generation
+	}
+	w.free = append([]uint32(nil), s.free...)
+	w.m.Unlock()
+
+	entities := make(map[EntityID]*Entity, len(s.entities))
+	for i, es := range s.entities {
+		e, err := restoreEntity(w, es.id, components[i]...)
+		if err != nil {
+			return err
+		}
+		entities[es.id] = e
+	}
+
+	isChild := make(map[EntityID]bool)
+	for _, es := range s.entities {
+		for _, id := range es.children {
+			if c, exists := entities[id]; exists {
+				entities[es.id].AddChild(c)
+				isChild[id] = true
+			}
+		}
+	}
+
+	for _, es := range s.entities {
+		if !isChild[es.id] {
+			w.addEntities([]*Entity{entities[es.id]}, false)
+		}
+	}
+	return nil
+}
+
+// Len returns the number of entities in the Snapshot
+func (s *Snapshot) Len() int {
+	return len(s.entities)
+}
+
+// Hash returns a hash of the Snapshot, 2 snapshots of the same state have the same hash.
+// Comparing the hashes of 2 worlds every frame detects when their states diverge
+func (s *Snapshot) Hash() uint64 {
+	h := fnv.New64a()
+	data, _ := s.MarshalBinary()
+	h.Write(data)
+	return h.Sum64()
+}
+
+// MarshalBinary returns the compact binary form of the Snapshot
+func (s *Snapshot) MarshalBinary() ([]byte, error) {
+	var buf []byte
+	buf = binary.AppendUvarint(buf, uint64(len(s.generations)))
+	for _, g := range s.generations {
+		buf = binary.AppendUvarint(buf, uint64(g))
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(s.free)))
+	for _, f := range s.free {
+		buf = binary.AppendUvarint(buf, uint64(f))
+	}
+
+	buf = binary.AppendUvarint(buf, uint64(len(s.entities)))
+	for _, es := range s.entities {
+		buf = binary.AppendUvarint(buf, uint64(es.id))
+		buf = binary.AppendUvarint(buf, uint64(len(es.children)))
+		for _, c := range es.children {
+			buf = binary.AppendUvarint(buf, uint64(c))
+		}
+		buf = binary.AppendUvarint(buf, uint64(len(es.components)))
+		for _, cs := range es.components {
+			buf = appendBytes(buf, []byte(cs.name))
+			buf = appendBytes(buf, cs.data)
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary reads a Snapshot from the binary form returned by [Snapshot.MarshalBinary]
+func (s *Snapshot) UnmarshalBinary(data []byte) error {
+	r := &snapshotReader{data: data}
+	var val Snapshot
+
+	val.generations = make([]uint32, r.length())
+	for i := range val.generations {
+		val.generations[i] = uint32(r.uvarint())
+	}
+	val.free = make([]uint32, r.length())
+	for i := range val.free {
+		val.free[i] = uint32(r.uvarint())
+	}
+
+	val.entities = make([]entitySnapshot, r.length())
+	for i := range val.entities {
+		es := &val.entities[i]
+		es.id = EntityID(r.uvarint())
+		if n := r.length(); n > 0 {
+			es.children = make([]EntityID, n)
+			for j := range es.children {
+				es.children[j] = EntityID(r.uvarint())
+			}
+		}
+		if n := r.length(); n > 0 {
+			es.components = make([]componentSnapshot, n)
+			for j := range es.components {
+				es.components[j] = componentSnapshot{string(r.bytes()), r.bytes()}
+			}
+		}
+	}
+
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.data) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidSnapshot, len(r.data))
+	}
+	*s = val
+	return nil
+}
+
+// appendBytes appends the length of b followed by b itself
+func appendBytes(buf, b []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+// snapshotReader reads the binary form of a Snapshot, keeping the first error that occurs
+type snapshotReader struct {
+	data []byte
+	err  error
+}
+
+func (r *snapshotReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = fmt.Errorf("%w: unexpected end of data", ErrInvalidSnapshot)
+		return 0
+	}
+	r.data = r.data[n:]
+	return v
+}
+
+// length reads a number of elements, which can never exceed the number of remaining bytes
+func (r *snapshotReader) length() int {
+	n := r.uvarint()
+	if n > uint64(len(r.data)) {
+		if r.err == nil {
+			r.err = fmt.Errorf("%w: length %d exceeds the data", ErrInvalidSnapshot, n)
+		}
+		return 0
+	}
+	return int(n)
+}
+
+func (r *snapshotReader) bytes() []byte {
+	n := r.length()
+	if r.err != nil {
+		return nil
+	}
+	val := r.data[:n:n]
+	r.data = r.data[n:]
+	return val
+}
+
+// DiffKind describes how an [Entity] or [Component] differs between 2 snapshots
+type DiffKind int
+
+const (
+	// DiffAdded means it only exists in the second Snapshot
+	DiffAdded DiffKind = iota
+	// DiffRemoved means it only exists in the first Snapshot
+	DiffRemoved
+	// DiffChanged means it exists in both snapshots, but its value or the children of the Entity differ
+	DiffChanged
+)
+
+// String returns the name of the DiffKind
+func (k DiffKind) String() string {
+	switch k {
+	case DiffAdded:
+		return "added"
+	case DiffRemoved:
+		return "removed"
+	default:
+		return "changed"
+	}
+}
+
+// Difference is a single difference between 2 snapshots.
+// Component is empty when the Entity itself was added or removed, or when its children changed
+type Difference struct {
+	Kind      DiffKind
+	Entity    EntityID
+	Component string
+}
+
+// String describes the Difference, i.e. "changed 3:1 Transform"
+func (d Difference) String() string {
+	if d.Component == "" {
+		return fmt.Sprintf("%s %s", d.Kind, d.Entity)
+	}
+	return fmt.Sprintf("%s %s %s", d.Kind, d.Entity, d.Component)
+}
+
+// DiffSnapshots returns the differences between the entities of 2 snapshots, ordered by the index of the entities.
+// Differences in the ID space alone are not reported, but do result in a different [Snapshot.Hash]
+func DiffSnapshots(a, b *Snapshot) []Difference {
+	before := make(map[EntityID]*entitySnapshot, len(a.entities))
+	for i := range a.entities {
+		before[a.entities[i].id] = &a.entities[i]
+	}
+	after := make(map[EntityID]*entitySnapshot, len(b.entities))
+	for i := range b.entities {
+		after[b.entities[i].id] = &b.entities[i]
+	}
+
+	var diffs []Difference
+	for _, es := range a.entities {
+		if _, exists := after[es.id]; !exists {
+			diffs = append(diffs, Difference{DiffRemoved, es.id, ""})
+		}
+	}
+	for _, es := range b.entities {
+		old, exists := before[es.id]
+		if !exists {
+			diffs = append(diffs, Difference{DiffAdded, es.id, ""})
+			continue
+		}
+		diffs = append(diffs, diffEntity(old, &es)...)
+	}
+
+	sort.SliceStable(diffs, func(i, j int) bool { return diffs[i].Entity.Index() < diffs[j].Entity.Index() })
+	return diffs
+}
+
+// diffEntity returns the differences between 2 snapshots of the same Entity
+func diffEntity(a, b *entitySnapshot) []Difference {
+	var diffs []Difference
+	if !slices.Equal(a.children, b.children) {
+		diffs = append(diffs, Difference{DiffChanged, b.id, ""})
+	}
+
+	i, j := 0, 0
+	for i < len(a.components) || j < len(b.components) {
+		switch {
+		case j == len(b.components) || (i < len(a.components) && a.components[i].name < b.components[j].name):
+			diffs = append(diffs, Difference{DiffRemoved, b.id, a.components[i].name})
+			i++
+		case i == len(a.components) || b.components[j].name < a.components[i].name:
+			diffs = append(diffs, Difference{DiffAdded, b.id, b.components[j].name})
+			j++
+		default:
+			if !bytes.Equal(a.components[i].data, b.components[j].data) {
+				diffs = append(diffs, Difference{DiffChanged, b.id, b.components[j].name})
+			}
+			i++
+			j++
+		}
+	}
+	return diffs
+}
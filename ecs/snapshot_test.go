@@ -0,0 +1,181 @@
+package ecs
+
+import (
+	"errors"
+	"testing"
+)
+
+// detachedLen returns the number of entities within the detached storage
+func detachedLen() int {
+	detached.m.RLock()
+	defer detached.m.RUnlock()
+
+	var n int
+	for _, a := range detached.order {
+		n += len(a.entities)
+	}
+	return n
+}
+
+func TestRestoreFreesEntities(t *testing.T) {
+	w := NewWorld()
+	w.NewEntity(&positionComponent{X: 1})
+	snap, err := w.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before := detachedLen()
+	old := w.NewEntity(&positionComponent{X: 2}, &TestComponent{})
+	if err := w.Restore(snap); err != nil {
+		t.Fatal(err)
+	}
+	if n := detachedLen(); n != before {
+		t.Errorf("Restore failed. Expected %d detached entities, but got %d", before, n)
+	}
+	if Has[*positionComponent](old) {
+		t.Errorf("Restore failed. Expected the components of the replaced entity to be freed")
+	}
+}
+
+func TestRestoreStaleHandle(t *testing.T) {
+	w := NewWorld()
+	old := w.NewEntity(&positionComponent{X: 1})
+	snap, err := w.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Restore(snap); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := old.ID()
+	old.Destroy()
+	if !w.Alive(restored) || w.Entity(restored) == old {
+		t.Errorf("Destroy failed. Expected the restored entity %s to stay alive", restored)
+	}
+	if e := w.NewEntity(); e.ID().Index() == restored.Index() {
+		t.Errorf("NewEntity failed. Expected a new index, but got the index of the restored entity %s", e.ID())
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	w := NewWorld()
+	ts := &QueryTestSystem{NewBaseSystem()}
+	w.AddSystems(ts)
+
+	root := w.NewEntity(&positionComponent{X: 1, Y: 2})
+	child := newEntity(w, &TestComponent{})
+	root.AddChild(child)
+
+	snap, err := w.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spawned := 0
+	Subscribe(w.Events(), func(EntitySpawned) { spawned++ })
+	pos, _ := Get[*positionComponent](root)
+	pos.X = 5
+	child.Destroy()
+	w.NewEntity(&TestComponent{})
+
+	if err := w.Restore(snap); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(w.Entities()) != 2 {
+		t.Fatalf("Restore failed. Expected 2 entities, but got %d", len(w.Entities()))
+	}
+	lr, lc := w.Entity(root.ID()), w.Entity(child.ID())
+	if lr == nil || lc == nil || lc.Parent() != lr {
+		t.Fatalf("Restore failed. Expected child %s of %s", child.ID(), root.ID())
+	}
+	if pos, ok := Get[*positionComponent](lr); !ok || pos.X != 1 {
+		t.Errorf("Restore failed. Expected position {1 2}, but got %v", pos)
+	}
+	if _, exists := ts.Entities()[lc.ID()]; !exists || len(ts.Entities()) != 1 {
+		t.Errorf("Restore failed. Expected the system to hold only the restored child, but got %d entities", len(ts.Entities()))
+	}
+	if spawned != 1 {
+		t.Errorf("Restore failed. Expected only the new entity to publish EntitySpawned, but got %d", spawned)
+	}
+
+	restored, err := w.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if restored.Hash() != snap.Hash() {
+		t.Errorf("Restore failed. Expected hash %x, but got %x", snap.Hash(), restored.Hash())
+	}
+}
+
+func TestSnapshotDeterministic(t *testing.T) {
+	build := func() *World {
+		w := NewWorld()
+		for i := 0; i < 10; i++ {
+			w.NewEntity(&positionComponent{X: float32(i)}, &TestComponent{})
+		}
+		return w
+	}
+
+	a, _ := build().Snapshot()
+	b, _ := build().Snapshot()
+	if a.Hash() != b.Hash() {
+		t.Errorf("Snapshot failed. Expected equal hashes, but got %x and %x", a.Hash(), b.Hash())
+	}
+}
+
+func TestSnapshotBinary(t *testing.T) {
+	w := NewWorld()
+	root := w.NewEntity(&positionComponent{X: 1, Y: 2})
+	root.AddChild(newEntity(w))
+
+	snap, _ := w.Snapshot()
+	data, err := snap.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Snapshot
+	if err := decoded.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Hash() != snap.Hash() || decoded.Len() != 2 {
+		t.Errorf("UnmarshalBinary failed. Expected hash %x, but got %x", snap.Hash(), decoded.Hash())
+	}
+
+	if err := decoded.UnmarshalBinary(data[:len(data)-1]); !errors.Is(err, ErrInvalidSnapshot) {
+		t.Errorf("UnmarshalBinary failed. Expected %v, but got %v", ErrInvalidSnapshot, err)
+	}
+}
+
+func TestDiffSnapshots(t *testing.T) {
+	w := NewWorld()
+	a := w.NewEntity(&positionComponent{X: 1})
+	b := w.NewEntity(&TestComponent{})
+	before, _ := w.Snapshot()
+
+	pos, _ := Get[*positionComponent](a)
+	pos.X = 2
+	a.AddComponent(&TestComponent{})
+	b.Destroy()
+	c := w.NewEntity()
+	after, _ := w.Snapshot()
+
+	expected := []Difference{
+		{DiffChanged, a.ID(), "PositionComponent"},
+		{DiffAdded, a.ID(), componentName},
+		{DiffRemoved, b.ID(), ""},
+		{DiffAdded, c.ID(), ""},
+	}
+	diffs := DiffSnapshots(before, after)
+	if len(diffs) != len(expected) {
+		t.Fatalf("DiffSnapshots failed. Expected %v, but got %v", expected, diffs)
+	}
+	for i := range expected {
+		if diffs[i] != expected[i] {
+			t.Errorf("DiffSnapshots failed. Expected %v, but got %v", expected[i], diffs[i])
+		}
+	}
+}
@@ -0,0 +1,320 @@
+package ecs
+
+import (
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// componentIDs assigns every component type and name a number, which makes up the signature of an archetype
+	componentIDs     = make(map[componentKey]int)
+	componentIDMutex = &sync.Mutex{}
+
+	// storageIDs is used to lock multiple storages in a consistent order
+	storageIDs uint64
+
+	// detached holds the components of entities that are not registered with a World, until they are destroyed
+	detached = newStorage()
+)
+
+// componentKey identifies a component by its type and name, as components of the same type can have different names
+type componentKey struct {
+	typ  reflect.Type
+	name string
+}
+
+// componentID returns the number of the component type and name, assigning a new one for unknown components
+func componentID(c Component) int {
+	key := componentKey{reflect.TypeOf(c), c.Name()}
+	componentIDMutex.Lock()
+	id, exists := componentIDs[key]
+	if !exists {
+		id = len(componentIDs)
+		componentIDs[key] = id
+	}
+	componentIDMutex.Unlock()
+	return id
+}
+
+// column holds the components of a single type for every entity of an archetype.
+// data is a slice of the component type, which keeps the components contiguous in memory
+type column struct {
+	typ  reflect.Type
+	name string
+	data reflect.Value
+}
+
+// archetype stores the entities that hold exactly the same set of components, identified by their type and name.
+// The component of the entity at entities[i] can be found at row i of each column.
+// byType holds the first column of each type, ordered by component id, which is used by the typed functions such as [Get]
+type archetype struct {
+	key      string
+	byType   map[reflect.Type]int
+	byName   map[string]int
+	columns  []*column
+	entities []*Entity
+}
+
+// storage holds the components of a group of entities, grouped in archetypes.
+// The archetype and row of an [Entity] are guarded by the lock of the storage it belongs to
+type storage struct {
+	id         uint64
+	m          *sync.RWMutex
+	archetypes map[string]*archetype
+	order      []*archetype
+}
+
+// newStorage creates an empty storage
+func newStorage() *storage {
+	return &storage{
+		id:         atomic.AddUint64(&storageIDs, 1),
+		m:          &sync.RWMutex{},
+		archetypes: make(map[string]*archetype),
+	}
+}
+
+// archetypeKey returns the key of the archetype that holds the given components, sorting the components by their id
+func archetypeKey(components []Component) string {
+	ids := make([]int, len(components))
+	for i, c := range components {
+		ids[i] = componentID(c)
+	}
+	sort.Sort(byID{ids, components})
+
+	var sb strings.Builder
+	for _, id := range ids {
+		sb.WriteString(strconv.Itoa(id))
+		sb.WriteByte(',')
+	}
+	return sb.String()
+}
+
+// archetypeFor returns the archetype that holds exactly the given components, creating it when it does not exist yet
+func (s *storage) archetypeFor(components []Component) *archetype {
+	key := archetypeKey(components)
+	if a, exists := s.archetypes[key]; exists {
+		return a
+	}
+
+	a := &archetype{
+		key:    key,
+		byType: make(map[reflect.Type]int, len(components)),
+		byName: make(map[string]int, len(components)),
+	}
+	for i, c := range components {
+		t := reflect.TypeOf(c)
+		if _, exists := a.byType[t]; !exists {
+			a.byType[t] = i
+		}
+		a.byName[c.Name()] = i
+		a.columns = append(a.columns, &column{
+			typ:  t,
+			name: c.Name(),
+			data: reflect.MakeSlice(reflect.SliceOf(t), 0, 0),
+		})
+	}
+
+	s.archetypes[key] = a
+	s.order = append(s.order, a)
+	return a
+}
+
+// insert places the entity with the given components into the storage
+func (s *storage) insert(e *Entity, components []Component) {
+	// Only keep the last component of each name
+	unique := make([]Component, 0, len(components))
+	names := make(map[string]int, len(components))
+	for _, c := range components {
+		if c == nil {
+			continue
+		}
+		if i, exists := names[c.Name()]; exists {
+			unique[i] = c
+			continue
+		}
+		names[c.Name()] = len(unique)
+		unique = append(unique, c)
+	}
+
+	a := s.archetypeFor(unique)
+	for _, c := range unique {
+		col := a.columns[a.byName[c.Name()]]
+		col.data = reflect.Append(col.data, reflect.ValueOf(c))
+	}
+	a.entities = append(a.entities, e)
+	e.arch = a
+	e.row = len(a.entities) - 1
+}
+
+// byID sorts components together with their ids
+type byID struct {
+	ids        []int
+	components []Component
+}
+
+func (b byID) Len() int           { return len(b.ids) }
+func (b byID) Less(i, j int) bool { return b.ids[i] < b.ids[j] }
+func (b byID) Swap(i, j int) {
+	b.ids[i], b.ids[j] = b.ids[j], b.ids[i]
+	b.components[i], b.components[j] = b.components[j], b.components[i]
+}
+
+// take removes the entity from the storage and returns its components
+func (s *storage) take(e *Entity) []Component {
+	a := e.arch
+	if a == nil {
+		return nil
+	}
+
+	components := s.components(e)
+	last := len(a.entities) - 1
+	for _, col := range a.columns {
+		if e.row != last {
+			col.data.Index(e.row).Set(col.data.Index(last))
+		}
+		col.data.Index(last).Set(reflect.Zero(col.typ))
+		col.data = col.data.Slice(0, last)
+	}
+
+	if e.row != last {
+		moved := a.entities[last]
+		a.entities[e.row] = moved
+		moved.row = e.row
+	}
+	a.entities[last] = nil
+	a.entities = a.entities[:last]
+
+	e.arch = nil
+	e.row = 0
+	return components
+}
+
+// components returns the components the entity holds
+func (s *storage) components(e *Entity) []Component {
+	if e.arch == nil {
+		return nil
+	}
+	components := make([]Component, len(e.arch.columns))
+	for i, col := range e.arch.columns {
+		components[i] = col.data.Index(e.row).Interface().(Component)
+	}
+	return components
+}
+
+// get returns the component at the given column of the entity
+func (s *storage) get(e *Entity, col int) Component {
+	return e.arch.columns[col].data.Index(e.row).Interface().(Component)
+}
+
+// set adds the component to the entity, replacing a component with the same name.
+// Replacing a component of the same name and type keeps the entity within its archetype
+func (s *storage) set(e *Entity, c Component) {
+	if e.arch != nil {
+		if col, exists := e.arch.byName[c.Name()]; exists && e.arch.columns[col].typ == reflect.TypeOf(c) {
+			e.arch.columns[col].data.Index(e.row).Set(reflect.ValueOf(c))
+			return
+		}
+	}
+
+	s.insert(e, append(s.take(e), c))
+}
+
+// unset removes the component with the given name from the entity
+func (s *storage) unset(e *Entity, name string) {
+	if e.arch == nil {
+		return
+	}
+	if _, exists := e.arch.byName[name]; !exists {
+		return
+	}
+
+	components := s.take(e)
+	remaining := components[:0]
+	for _, c := range components {
+		if c.Name() != name {
+			remaining = append(remaining, c)
+		}
+	}
+	s.insert(e, remaining)
+}
+
+// matching returns the archetypes holding entities that have a component of every given type.
+// The caller must hold the lock of the storage
+func (s *storage) matching(types ...reflect.Type) []*archetype {
+	var val []*archetype
+	for _, a := range s.order {
+		if len(a.entities) == 0 {
+			continue
+		}
+		match := true
+		for _, t := range types {
+			if _, exists := a.byType[t]; !exists {
+				match = false
+				break
+			}
+		}
+		if match {
+			val = append(val, a)
+		}
+	}
+	return val
+}
+
+// columnOf returns the contiguous slice of components of type T within the archetype.
+// The caller must hold the lock of the storage
+func columnOf[T Component](a *archetype) []T {
+	return a.columns[a.byType[typeOf[T]()]].data.Interface().([]T)
+}
+
+// migrate moves the entity with all of its components into another storage
+func migrate(e *Entity, to *storage) {
+	from := e.store.Load()
+	if from == to {
+		return
+	}
+
+	first, second := from, to
+	if second.id < first.id {
+		first, second = second, first
+	}
+	first.m.Lock()
+	second.m.Lock()
+
+	alive := e.arch != nil
+	components := from.take(e)
+	if alive {
+		to.insert(e, components)
+	}
+	e.store.Store(to)
+
+	second.m.Unlock()
+	first.m.Unlock()
+}
+
+// readStorage read locks and returns the storage the entity belongs to
+func (e *Entity) readStorage() *storage {
+	for {
+		s := e.store.Load()
+		s.m.RLock()
+		if e.store.Load() == s {
+			return s
+		}
+		s.m.RUnlock()
+	}
+}
+
+// writeStorage locks and returns the storage the entity belongs to
+func (e *Entity) writeStorage() *storage {
+	for {
+		s := e.store.Load()
+		s.m.Lock()
+		if e.store.Load() == s {
+			return s
+		}
+		s.m.Unlock()
+	}
+}
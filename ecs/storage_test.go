@@ -0,0 +1,130 @@
+package ecs
+
+import "testing"
+
+func TestStorageArchetypes(t *testing.T) {
+	w := NewWorld()
+	entities := make([]*Entity, 4)
+	for i := range entities {
+		entities[i] = w.NewEntity(&positionComponent{X: float32(i)})
+	}
+
+	// Moving an entity out of the middle of an archetype must keep the rows of the other entities intact
+	entities[1].AddComponent(&velocityComponent{X: 1})
+	entities[0].Destroy()
+
+	for i, e := range entities[1:] {
+		pos, ok := Get[*positionComponent](e)
+		if !ok || pos.X != float32(i+1) {
+			t.Errorf("Get failed. Expected position %d, but got %v", i+1, pos)
+		}
+	}
+
+	if !Has[*velocityComponent](entities[1]) || Has[*velocityComponent](entities[2]) {
+		t.Errorf("AddComponent failed. Expected only the second entity to hold a velocity")
+	}
+
+	if entities[0].HasComponent("PositionComponent") {
+		t.Errorf("Destroy failed. Expected the components of the entity to be removed")
+	}
+
+	if len(w.storage.order) != 2 {
+		t.Errorf("AddComponent failed. Expected 2 archetypes, but got %d", len(w.storage.order))
+	}
+}
+
+func TestStorageMigrate(t *testing.T) {
+	w1, w2 := NewWorld(), NewWorld()
+	pos := &positionComponent{X: 1}
+	e := NewEntity(pos)
+
+	w1.AddEntities(e)
+	w2.AddEntities(e)
+	if result, _ := Get[*positionComponent](e); result != pos {
+		t.Errorf("AddEntities failed. Expected components to move along with the entity")
+	}
+
+	for range Query1[*positionComponent](w1) {
+		t.Errorf("Query1 failed. Expected no entities in the previous world")
+	}
+
+	w2.RemoveEntity(e)
+	for range Query1[*positionComponent](w2) {
+		t.Errorf("Query1 failed. Expected unregistered entities to be excluded")
+	}
+	if !Has[*positionComponent](e) {
+		t.Errorf("RemoveEntity failed. Expected entity to keep its components")
+	}
+}
+
+// namedComponent is a component type whose name is set per value
+type namedComponent struct {
+	name  string
+	value int
+}
+
+func (n *namedComponent) Name() string { return n.name }
+
+func TestStorageSameTypeDifferentNames(t *testing.T) {
+	w := NewWorld()
+	a, b := &namedComponent{"A", 1}, &namedComponent{"B", 2}
+	e := w.NewEntity(a, b)
+	if e.Component("A") != a || e.Component("B") != b {
+		t.Errorf("NewEntity failed. Expected both components, but got %v and %v", e.Component("A"), e.Component("B"))
+	}
+
+	// adding a component of a type the entity already holds under another name
+	c := &namedComponent{"C", 3}
+	single := w.NewEntity(&namedComponent{"A", 0})
+	single.AddComponent(c)
+	if single.Component("A") == nil || single.Component("C") != c {
+		t.Errorf("AddComponent failed. Expected components A and C, but got %v and %v", single.Component("A"), single.Component("C"))
+	}
+
+	// replacing one of them keeps the other intact
+	replaced := &namedComponent{"B", 4}
+	e.AddComponent(replaced)
+	if e.Component("A") != a || e.Component("B") != replaced {
+		t.Errorf("AddComponent failed. Expected A to be kept and B to be replaced, but got %v and %v", e.Component("A"), e.Component("B"))
+	}
+
+	e.RemoveComponent(a)
+	if e.Component("A") != nil || e.Component("B") != replaced {
+		t.Errorf("RemoveComponent failed. Expected only B to remain, but got %v and %v", e.Component("A"), e.Component("B"))
+	}
+}
+
+// newBenchmarkWorld creates a World with a System that holds n entities with a position and velocity
+func newBenchmarkWorld(n int) (*World, System) {
+	w := NewWorld()
+	s := &accessSystem{BaseSystem: NewBaseSystem(), name: "Movement"}
+	w.AddSystems(s)
+	for i := 0; i < n; i++ {
+		w.NewEntity(&TestComponent{}, &positionComponent{}, &velocityComponent{1, 1})
+	}
+	return w, s
+}
+
+func BenchmarkIterateSystemEntities(b *testing.B) {
+	_, s := newBenchmarkWorld(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, e := range s.Entities() {
+			pos := e.Component("PositionComponent").(*positionComponent)
+			vel := e.Component("VelocityComponent").(*velocityComponent)
+			pos.X += vel.X
+			pos.Y += vel.Y
+		}
+	}
+}
+
+func BenchmarkIterateQuery2(b *testing.B) {
+	w, _ := newBenchmarkWorld(10000)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, c := range Query2[*positionComponent, *velocityComponent](w) {
+			c.A.X += c.B.X
+			c.A.Y += c.B.Y
+		}
+	}
+}
@@ -6,21 +6,54 @@ import (
 
 //System handles updates on entities based on the components they withold
 type System interface {
-	//Update is run on every system to update the entities
+	//Update is run on every system to update the entities, dt is the time passed since the previous update.
+	//Breaking change: the run loop of rebound passes dt in seconds, where it used to pass milliseconds,
+	//so systems written for milliseconds have to multiply dt by 1000
 	Update(dt float64)
 	//AddEntities allow for 1 or more entities to be added to the system
 	AddEntities(...*Entity)
 	//Entities returns a map of entities the system contains
-	Entities() map[int32]*Entity
+	Entities() map[EntityID]*Entity
 	//RemoveEntity removes a singular entity from the system
 	RemoveEntity(e *Entity)
 	//Name returns the name of the system
 	Name() string
 }
 
+//Initializer is implemented by a [System] that has to be set up when it is added to a [World]
+type Initializer interface {
+	//Init is called once when the system is added to the world, returning an error prevents the system from being added
+	Init(w *World) error
+}
+
+//Shutdowner is implemented by a [System] that has to clean up when it is removed or the [World] shuts down
+type Shutdowner interface {
+	//Shutdown is called when the system is removed from the world or when the world shuts down
+	Shutdown()
+}
+
+//EntityAddedHandler is implemented by a [System] that wants to be notified when the [World] adds an [Entity] to it
+type EntityAddedHandler interface {
+	//OnEntityAdded is called after the entity has been added to the system
+	OnEntityAdded(e *Entity)
+}
+
+//EntityRemovedHandler is implemented by a [System] that wants to be notified when the [World] removes an [Entity] from it
+type EntityRemovedHandler interface {
+	//OnEntityRemoved is called after the entity has been removed from the system
+	OnEntityRemoved(e *Entity)
+}
+
+//Interpolator is implemented by a [System] in [StageRender] that blends the state of the last two fixed updates
+type Interpolator interface {
+	//Interpolate is called before the system is updated, with the fraction of the fixed time step that has passed since the last fixed update.
+	//The alpha is 1 when the world is updated with a variable time step
+	Interpolate(alpha float64)
+}
+
 //BaseSystem is a base implementation of [System]. However, requires an [Update] function to meet the requirements of the interface
 type BaseSystem struct {
-	entities map[int32]*Entity
+	entities map[EntityID]*Entity
 	m        *sync.RWMutex
 }
 
@@ -28,21 +61,22 @@ type BaseSystem struct {
 func NewBaseSystem() BaseSystem {
 	return BaseSystem{
 		m:        &sync.RWMutex{},
-		entities: make(map[int32]*Entity),
+		entities: make(map[EntityID]*Entity),
 	}
 }
 
 //AddEntities adds one or multiple entities to the system using the provided checker.
 //It only adds entities that meet the checker's requirements.
 func (bs *BaseSystem) AddEntities(entities ...*Entity) {
+	bs.m.Lock()
 	for _, e := range entities {
-		bs.entities[e.id] = e
+		bs.entities[e.ID()] = e
 	}
-
+	bs.m.Unlock()
 }
 
 //Entities returns a map of entities where its key is the [ID()] of the Entity
-func (bs *BaseSystem) Entities() map[int32]*Entity {
+func (bs *BaseSystem) Entities() map[EntityID]*Entity {
 	bs.m.RLock()
 	val := bs.entities
 	bs.m.RUnlock()
@@ -52,6 +86,6 @@ func (bs *BaseSystem) Entities() map[int32]*Entity {
 //RemoveEntity removes the given [Entity] from the [System]
 func (bs *BaseSystem) RemoveEntity(e *Entity) {
 	bs.m.Lock()
-	delete(bs.entities, e.id)
+	delete(bs.entities, e.ID())
 	bs.m.Unlock()
 }
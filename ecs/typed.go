@@ -0,0 +1,107 @@
+package ecs
+
+import (
+	"iter"
+	"reflect"
+)
+
+// typeOf returns the type of T, which is used as the key of a component
+func typeOf[T Component]() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Get returns the component of type T the [Entity] holds.
+// The boolean is false when the Entity does not hold a component of type T
+func Get[T Component](e *Entity) (T, bool) {
+	c, ok := e.componentOfType(typeOf[T]()).(T)
+	return c, ok
+}
+
+// Has returns true if the [Entity] holds a component of type T
+func Has[T Component](e *Entity) bool {
+	return e.componentOfType(typeOf[T]()) != nil
+}
+
+// Add adds the component to the [Entity] like [Entity.AddComponent], replacing the component with the same Name.
+// Components are identified by their Name, so a component of another type with the same Name is replaced as well,
+// after which [Get] no longer returns it
+func Add[T Component](e *Entity, c T) {
+	e.AddComponent(c)
+}
+
+// Remove removes the component of type T from the [Entity].
+// Returns false when the Entity did not hold a component of type T
+func Remove[T Component](e *Entity) bool {
+	c := e.componentOfType(typeOf[T]())
+	if c == nil {
+		return false
+	}
+	e.RemoveComponent(c)
+	return true
+}
+
+// Query1 iterates every [Entity] registered with the [World] that holds a component of type A.
+// The components are read directly from the archetype storage of the World, which is not locked while iterating.
+// The World must not be changed during the iteration, use [Commands] to add or remove components or entities instead
+//
+//	for e, pos := range ecs.Query1[*Position](world) {
+//		...
+//	}
+func Query1[A Component](w *World) iter.Seq2[*Entity, A] {
+	return func(yield func(*Entity, A) bool) {
+		w.storage.m.RLock()
+		archetypes := w.storage.matching(typeOf[A]())
+		entities := make([][]*Entity, len(archetypes))
+		as := make([][]A, len(archetypes))
+		for i, arch := range archetypes {
+			entities[i] = arch.entities
+			as[i] = columnOf[A](arch)
+		}
+		w.storage.m.RUnlock()
+
+		for i := range archetypes {
+			for row, a := range as[i] {
+				if !yield(entities[i][row], a) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Pair holds the components of an [Entity] yielded by [Query2]
+type Pair[A, B Component] struct {
+	A A
+	B B
+}
+
+// Query2 iterates every [Entity] registered with the [World] that holds a component of both type A and type B.
+// The components are read directly from the archetype storage of the World, which is not locked while iterating.
+// The World must not be changed during the iteration, use [Commands] to add or remove components or entities instead
+//
+//	for e, c := range ecs.Query2[*Position, *Velocity](world) {
+//		c.A.X += c.B.X
+//	}
+func Query2[A, B Component](w *World) iter.Seq2[*Entity, Pair[A, B]] {
+	return func(yield func(*Entity, Pair[A, B]) bool) {
+		w.storage.m.RLock()
+		archetypes := w.storage.matching(typeOf[A](), typeOf[B]())
+		entities := make([][]*Entity, len(archetypes))
+		as := make([][]A, len(archetypes))
+		bs := make([][]B, len(archetypes))
+		for i, arch := range archetypes {
+			entities[i] = arch.entities
+			as[i] = columnOf[A](arch)
+			bs[i] = columnOf[B](arch)
+		}
+		w.storage.m.RUnlock()
+
+		for i := range archetypes {
+			for row, a := range as[i] {
+				if !yield(entities[i][row], Pair[A, B]{a, bs[i][row]}) {
+					return
+				}
+			}
+		}
+	}
+}
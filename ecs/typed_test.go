@@ -0,0 +1,110 @@
+package ecs
+
+import "testing"
+
+type positionComponent struct {
+	X, Y float32
+}
+
+func (p *positionComponent) Name() string {
+	return "PositionComponent"
+}
+
+type velocityComponent struct {
+	X, Y float32
+}
+
+func (v *velocityComponent) Name() string {
+	return "VelocityComponent"
+}
+
+// otherPosition is a component of another type that shares the name of positionComponent
+type otherPosition struct {
+	X float32
+}
+
+func (o *otherPosition) Name() string {
+	return "PositionComponent"
+}
+
+func TestTypedAccessors(t *testing.T) {
+	e := NewEntity()
+
+	if _, ok := Get[*positionComponent](e); ok {
+		t.Errorf("Get failed. Expected no component")
+	}
+
+	pos := &positionComponent{1, 2}
+	Add(e, pos)
+
+	if !Has[*positionComponent](e) || !e.HasComponent("PositionComponent") {
+		t.Errorf("Add failed. Expected entity to hold the component")
+	}
+
+	if result, ok := Get[*positionComponent](e); !ok || result != pos {
+		t.Errorf("Get failed. Expected %v, but got %v", pos, result)
+	}
+
+	if Has[*velocityComponent](e) {
+		t.Errorf("Has failed. Expected entity not to hold a velocity component")
+	}
+
+	if !Remove[*positionComponent](e) || Has[*positionComponent](e) {
+		t.Errorf("Remove failed. Expected component to be removed")
+	}
+
+	if Remove[*positionComponent](e) {
+		t.Errorf("Remove failed. Expected false when the component does not exist")
+	}
+}
+
+func TestTypedNameClash(t *testing.T) {
+	e := NewEntity()
+	Add(e, &positionComponent{1, 2})
+	Add(e, &otherPosition{3})
+
+	if _, ok := Get[*positionComponent](e); ok {
+		t.Errorf("Add failed. Expected the component with the same name to be replaced")
+	}
+	if other, ok := Get[*otherPosition](e); !ok || other.X != 3 {
+		t.Errorf("Get failed. Expected %v, but got %v", 3, other)
+	}
+}
+
+func TestTypedQueries(t *testing.T) {
+	w := NewWorld()
+	w.NewEntity(&positionComponent{}, &velocityComponent{1, 1})
+	w.NewEntity(&positionComponent{}, &velocityComponent{2, 2})
+	w.NewEntity(&positionComponent{})
+
+	var count int
+	for e, pos := range Query1[*positionComponent](w) {
+		if e == nil || pos == nil {
+			t.Errorf("Query1 failed. Expected an entity and component")
+		}
+		count++
+	}
+	if count != 3 {
+		t.Errorf("Query1 failed. Expected 3 entities, but got %d", count)
+	}
+
+	count = 0
+	for e, c := range Query2[*positionComponent, *velocityComponent](w) {
+		if pos, _ := Get[*positionComponent](e); pos != c.A {
+			t.Errorf("Query2 failed. Expected the entity holding the components")
+		}
+		c.A.X += c.B.X
+		count++
+	}
+	if count != 2 {
+		t.Errorf("Query2 failed. Expected 2 entities, but got %d", count)
+	}
+
+	var sum float32
+	for _, pos := range Query1[*positionComponent](w) {
+		sum += pos.X
+	}
+	if sum != 3 {
+		t.Errorf("Query2 failed. Expected a sum of 3, but got %v", sum)
+	}
+}
@@ -0,0 +1,158 @@
+package ecs
+
+import (
+	"sync"
+)
+
+var (
+	defaultWorld     *World
+	defaultWorldOnce sync.Once
+)
+
+// World owns its own entities, systems and entity ID space.
+// Multiple worlds can exist next to each other without sharing any state.
+type World struct {
+	m              *sync.RWMutex
+	slots          []slot
+	free           []uint32
+	systems        []System
+	batches        [][]System
+	entities       map[EntityID]*Entity
+	checkAccess    bool
+	removeHooks    []RemoveHook
+	commands       *Commands
+	systemCommands map[System]*Commands
+	storage        *storage
+	disabled       map[string]bool
+	paused         bool
+	bus            *Bus
+}
+
+// RemoveHook is called for every [Entity] that is removed from a World
+type RemoveHook func(e *Entity)
+
+// NewWorld creates a new, empty World
+func NewWorld() *World {
+	return &World{
+		m:              &sync.RWMutex{},
+		systems:        []System{},
+		entities:       make(map[EntityID]*Entity),
+		systemCommands: make(map[System]*Commands),
+		storage:        newStorage(),
+		disabled:       make(map[string]bool),
+		bus:            NewBus(),
+	}
+}
+
+// DefaultWorld returns the World that is used when no World is provided explicitly.
+// Entities created with [NewEntity] receive their ID from the default World.
+func DefaultWorld() *World {
+	defaultWorldOnce.Do(func() {
+		defaultWorld = NewWorld()
+	})
+	return defaultWorld
+}
+
+// NewEntity creates a new [Entity] with the given components and registers it with the World
+func (w *World) NewEntity(components ...Component) *Entity {
+	e := newEntity(w, components...)
+	w.AddEntities(e)
+	return e
+}
+
+// AddEntities registers one or more entities, including their children, with the World.
+// Each [Entity] is added to every [Querier] [System] whose [Query] it matches,
+// and is kept up to date as components are added or removed.
+// An [Entity] whose ID was allocated by another World receives a new ID from this World.
+// [EntitySpawned] is published for every [Entity] that was not registered with the World yet
+func (w *World) AddEntities(entities ...*Entity) {
+	w.addEntities(entities, true)
+}
+
+// addEntities registers the entities and their children, publishing [EntitySpawned] for new entities when publish is true
+func (w *World) addEntities(entities []*Entity, publish bool) {
+	for _, e := range entities {
+		if old := e.World(); old != nil && old != w {
+			old.RemoveEntity(e)
+		}
+		e.setWorld(w)
+		id := e.ID()
+
+		w.m.Lock()
+		_, exists := w.entities[id]
+		w.entities[id] = e
+		w.m.Unlock()
+
+		w.refresh(e)
+		if !exists && publish {
+			Publish(w.bus, EntitySpawned{e})
+		}
+		w.addEntities(e.Children(), publish)
+	}
+}
+
+// RemoveEntity unregisters the [Entity] and its children from the World and removes them from every [System].
+// The remove hooks of the World are run after the [Entity] is removed from the systems.
+// The Entity keeps its ID and components, which are freed once [Entity.Destroy] is called
+func (w *World) RemoveEntity(e *Entity) {
+	id := e.ID()
+	w.m.Lock()
+	_, exists := w.entities[id]
+	delete(w.entities, id)
+	hooks := w.removeHooks
+	w.m.Unlock()
+
+	e.unsetWorld()
+
+	for _, s := range w.Systems() {
+		if _, exists := s.Entities()[id]; exists {
+			removeFromSystem(s, e)
+		}
+	}
+
+	if exists {
+		for _, hook := range hooks {
+			hook(e)
+		}
+	}
+
+	for _, c := range e.Children() {
+		w.RemoveEntity(c)
+	}
+}
+
+// OnRemove adds a hook that is called whenever an [Entity] is removed from the World, i.e. when it is destroyed
+func (w *World) OnRemove(hook RemoveHook) {
+	w.m.Lock()
+	w.removeHooks = append(w.removeHooks, hook)
+	w.m.Unlock()
+}
+
+// Entities returns the entities registered with the World, where its key is the [ID()] of the Entity
+func (w *World) Entities() map[EntityID]*Entity {
+	w.m.RLock()
+	val := make(map[EntityID]*Entity, len(w.entities))
+	for id, e := range w.entities {
+		val[id] = e
+	}
+	w.m.RUnlock()
+	return val
+}
+
+// refresh adds the [Entity] to or removes it from each [Querier] [System], based on the components it currently holds
+func (w *World) refresh(e *Entity) {
+	for _, s := range w.Systems() {
+		q, ok := s.(Querier)
+		if !ok {
+			continue
+		}
+
+		_, exists := s.Entities()[e.ID()]
+		match := q.Query().Matches(e)
+		if match && !exists {
+			addToSystem(s, e)
+		} else if !match && exists {
+			removeFromSystem(s, e)
+		}
+	}
+}
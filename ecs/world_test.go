@@ -0,0 +1,82 @@
+package ecs
+
+import "testing"
+
+func TestWorldIDSpace(t *testing.T) {
+	w1, w2 := NewWorld(), NewWorld()
+
+	e1 := w1.NewEntity()
+	e2 := w2.NewEntity()
+
+	if e1.ID() != e2.ID() {
+		t.Errorf("NewEntity failed. Expected worlds to have their own ID space, but got %v and %v", e1.ID(), e2.ID())
+	}
+
+	if e1.World() != w1 || e2.World() != w2 {
+		t.Errorf("NewEntity failed. Expected entities to be registered with their own world")
+	}
+}
+
+func TestWorldsAreIndependent(t *testing.T) {
+	w1, w2 := NewWorld(), NewWorld()
+	ts1 := &QueryTestSystem{NewBaseSystem()}
+	ts2 := &QueryTestSystem{NewBaseSystem()}
+	w1.AddSystems(ts1)
+	w2.AddSystems(ts2)
+
+	w1.NewEntity(&TestComponent{})
+
+	if len(ts1.Entities()) != 1 {
+		t.Errorf("NewEntity failed. Expected 1 entity, but got %d", len(ts1.Entities()))
+	}
+
+	if len(ts2.Entities()) != 0 {
+		t.Errorf("NewEntity failed. Expected entity to stay within its world, but got %d entities", len(ts2.Entities()))
+	}
+}
+
+func TestWorldMoveEntity(t *testing.T) {
+	w1, w2 := NewWorld(), NewWorld()
+	w2.NewEntity()
+
+	e := w1.NewEntity(&TestComponent{})
+	w2.AddEntities(e)
+
+	if e.World() != w2 {
+		t.Errorf("AddEntities failed. Expected entity to be registered with the new world")
+	}
+
+	if _, exists := w1.Entities()[e.ID()]; exists {
+		t.Errorf("AddEntities failed. Expected entity to be removed from its previous world")
+	}
+
+	if e.ID().Index() != 1 {
+		t.Errorf("AddEntities failed. Expected an ID from the new world's ID space, but got %v", e.ID())
+	}
+}
+
+func TestWorldRecycleID(t *testing.T) {
+	w := NewWorld()
+	e := w.NewEntity()
+	id := e.ID()
+
+	if !w.Alive(id) || w.Entity(id) != e {
+		t.Errorf("Alive failed. Expected entity %v to be alive", id)
+	}
+
+	e.Destroy()
+	if w.Alive(id) || w.Entity(id) != nil {
+		t.Errorf("Alive failed. Expected entity %v to be destroyed", id)
+	}
+
+	reused := w.NewEntity()
+	if reused.ID().Index() != id.Index() {
+		t.Errorf("NewEntity failed. Expected index %d to be reused, but got %d", id.Index(), reused.ID().Index())
+	}
+	if reused.ID().Generation() != id.Generation()+1 {
+		t.Errorf("NewEntity failed. Expected generation %d, but got %d", id.Generation()+1, reused.ID().Generation())
+	}
+	if w.Alive(id) || !w.Alive(reused.ID()) {
+		t.Errorf("Alive failed. Expected only the new ID to be alive")
+	}
+}
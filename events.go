@@ -0,0 +1,58 @@
+package rebound
+
+import (
+	"github.com/luukdegram/rebound/ecs"
+	"github.com/luukdegram/rebound/input"
+	"github.com/luukdegram/rebound/internal/display"
+)
+
+// KeyEvent is queued on the event bus of the world when a key is pressed or released
+type KeyEvent struct {
+	Key     input.Key
+	Pressed bool
+}
+
+// MouseButtonEvent is queued on the event bus of the world when a mouse button is pressed or released
+type MouseButtonEvent struct {
+	Button  input.MouseButton
+	Pressed bool
+}
+
+// WindowResizeEvent is queued on the event bus of the world when the window is resized
+type WindowResizeEvent struct {
+	Width  int
+	Height int
+}
+
+// WindowFocusEvent is queued on the event bus of the world when the window gains or loses focus
+type WindowFocusEvent struct {
+	Focused bool
+}
+
+// WindowCloseEvent is queued on the event bus of the world when the user closes the window
+type WindowCloseEvent struct{}
+
+// windowEvents queues the events of the window on an event bus, so they are delivered during the next update of the world
+type windowEvents struct {
+	bus *ecs.Bus
+}
+
+func (we windowEvents) KeyEvent(key input.Key, pressed bool) {
+	ecs.Enqueue(we.bus, KeyEvent{key, pressed})
+}
+
+func (we windowEvents) MouseButtonEvent(button input.MouseButton, pressed bool) {
+	ecs.Enqueue(we.bus, MouseButtonEvent{button, pressed})
+}
+
+func (we windowEvents) ResizeEvent(size display.Size) {
+	ecs.Enqueue(we.bus, WindowResizeEvent{size.Width, size.Height})
+}
+
+func (we windowEvents) FocusEvent(focused bool) {
+	ecs.Enqueue(we.bus, WindowFocusEvent{focused})
+}
+
+func (we windowEvents) CloseEvent() {
+	ecs.Enqueue(we.bus, WindowCloseEvent{})
+}
@@ -28,9 +28,11 @@ type inputSystem struct {
 	ecs.BaseSystem
 	Camera *rebound.Camera
 	Speed  float32
-	rs     *rebound.RenderSystem // Solely for demo purposes, you probably wouldn't want this
 }
 
+// togglePolygonsEvent is queued by the input system to switch the renderer to polygon mode
+type togglePolygonsEvent struct{}
+
 func (is *inputSystem) Update(dt float64) {
 	dist := float32(dt) * is.Speed
 	if input.KeyW.Down() {
@@ -46,7 +48,7 @@ func (is *inputSystem) Update(dt float64) {
 		is.Camera.Move(dist, 0, 0)
 	}
 	if input.KeyP.Down() {
-		is.rs.TogglePolygons()
+		ecs.Enqueue(ecs.GetManager().Events(), togglePolygonsEvent{})
 	}
 	if input.KeyQ.Down() {
 		is.Camera.Move(0, dist, 0)
@@ -66,7 +68,7 @@ func (is *inputSystem) Name() string {
 
 func setup() {
 	gltfImporter := importers.GLTFImporter{}
-	scene, err := gltfImporter.Import("gltf_objects/SciFiHelmet/glTF/SciFiHelmet.gltf")
+	helmet, err := gltfImporter.Prefab("gltf_objects/SciFiHelmet/glTF/SciFiHelmet.gltf")
 	if err != nil {
 		panic(err)
 	}
@@ -87,25 +89,28 @@ func setup() {
 	if err != nil {
 		panic(err)
 	}
-	renderer.AddEntities(scene)
 	renderer.NewCamera(width, height)
 	renderer.Camera.MoveTo(0, 0, 12.5)
 	renderer.Skybox = skybox
 
+	// Render the helmets physically based, using all textures of their materials
+	ps, err := rebound.NewPBRShader()
+	if err != nil {
+		panic(err)
+	}
+	renderer.Shader = ps
+
 	// Let's create some point lights for in the scene
-	bs := renderer.Shader.(*rebound.BasicShader)
 	size := 4
 	counter := 0
 	for counter < size {
 		x := rand.Float32()*10 - 5
 		y := rand.Float32()*10 - 5
 		z := rand.Float32()*10 - 5
-		bs.PointLights = append(bs.PointLights, rebound.PointLight{
+		ps.PointLights = append(ps.PointLights, rebound.PointLight{
 			Light: rebound.Light{
 				Position: [3]float32{x, y, z},
-				Ambient:  [3]float32{0.05, 0.05, 0.05},
-				Diffuse:  [3]float32{0.8, 0.8, 0.8},
-				Specular: [3]float32{1, 1, 1},
+				Colour:   [3]float32{10, 10, 10},
 			},
 			Constant:  1,
 			Linear:    0.09,
@@ -114,15 +119,57 @@ func setup() {
 		counter++
 	}
 
-	inputSystem := &inputSystem{ecs.NewBaseSystem(), renderer.Camera, 150, renderer}
-	ecs.GetManager().AddSystems(renderer, inputSystem)
+	ecs.Subscribe(ecs.GetManager().Events(), func(togglePolygonsEvent) {
+		renderer.TogglePolygons()
+	})
+
+	// Press O to save a screenshot and V to start or stop capturing a video
+	ecs.Subscribe(ecs.GetManager().Events(), func(e rebound.KeyEvent) {
+		if !e.Pressed {
+			return
+		}
+		switch e.Key {
+		case input.KeyO:
+			if path, err := renderer.SaveScreenshot("screenshots"); err != nil {
+				log.Println("screenshot:", err)
+			} else {
+				log.Println("saved", path)
+			}
+		case input.KeyV:
+			var err error
+			if rebound.Capturing() {
+				err = rebound.StopCapture()
+			} else {
+				err = rebound.StartCapture(rebound.CaptureOptions{Format: rebound.CaptureY4M, Path: "captures/helmet.y4m"})
+			}
+			if err != nil {
+				log.Println("capture:", err)
+			}
+		}
+	})
+
+	inputSystem := &inputSystem{ecs.NewBaseSystem(), renderer.Camera, 5}
+	if err := ecs.GetManager().AddSystems(rebound.NewTransformSystem(), renderer, inputSystem); err != nil {
+		panic(err)
+	}
+
+	// Place a few helmets next to each other, sharing the same meshes
+	for i := -1; i <= 1; i++ {
+		x := float32(i) * 5
+		helmet.InstantiateIn(ecs.GetManager(), func(e *ecs.Entity) {
+			if t, ok := ecs.Get[*rebound.Transform](e); ok {
+				t.Position = [3]float32{x, 0, 0}
+			}
+		})
+	}
 }
 
 func main() {
 	options := rebound.RunOptions{
-		Height: height,
-		Width:  width,
-		Title:  "Rebound Engine",
+		Height:    height,
+		Width:     width,
+		Title:     "Rebound Engine",
+		FixedRate: 60,
 	}
 	if err := rebound.Run(options, setup); err != nil {
 		fmt.Println(err)
@@ -1,6 +1,6 @@
 module github.com/luukdegram/rebound
 
-go 1.14
+go 1.23
 
 require (
 	github.com/go-gl/gl v0.0.0-20190320180904-bf2b1f2f34d7
@@ -8,3 +8,5 @@ require (
 	github.com/go-gl/mathgl v0.0.0-20190713194549-592312d8590a
 	github.com/qmuntal/gltf v0.14.1
 )
+
+require golang.org/x/image v0.0.0-20190321063152-3fc05d484e9f // indirect
@@ -0,0 +1,182 @@
+package rebound_test
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"testing"
+
+	"github.com/luukdegram/rebound"
+	"github.com/luukdegram/rebound/ecs"
+	"github.com/luukdegram/rebound/importers"
+	"github.com/luukdegram/rebound/rendertest"
+)
+
+const helmetFile = "examples/assets/gltf_objects/SciFiHelmet/glTF/SciFiHelmet.gltf"
+
+var skyboxFaces = [6]string{
+	"examples/assets/skybox/right.png",
+	"examples/assets/skybox/left.png",
+	"examples/assets/skybox/top.png",
+	"examples/assets/skybox/bottom.png",
+	"examples/assets/skybox/back.png",
+	"examples/assets/skybox/front.png",
+}
+
+// TestGoldenHelmet renders the SciFiHelmet example, which is skipped as long as its textures are not part of the repository
+func TestGoldenHelmet(t *testing.T) {
+	if _, err := os.Stat("examples/assets/gltf_objects/SciFiHelmet/glTF/SciFiHelmet_BaseColor.png"); err != nil {
+		t.Skipf("textures of %s are not available: %v", helmetFile, err)
+	}
+
+	img := rendertest.Render(t, 256, 256, func(w *ecs.World, rs *rebound.RenderSystem) {
+		rs.Camera.MoveTo(0, 0, 3)
+		usePBR(t, rs)
+
+		helmet, err := (&importers.GLTFImporter{}).Import(helmetFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.AddEntities(helmet)
+	})
+	rendertest.Golden(t, "helmet", img, rendertest.Options{MaxDiff: 0.001})
+}
+
+// TestGoldenGLTF renders a textured cube of the test data, which uses a texture for every part of its material
+func TestGoldenGLTF(t *testing.T) {
+	img := rendertest.Render(t, 128, 128, func(w *ecs.World, rs *rebound.RenderSystem) {
+		rs.Camera.MoveTo(0, 0, 5)
+		usePBR(t, rs)
+
+		cube, err := (&importers.GLTFImporter{}).Import("testdata/gltf/cube.gltf")
+		if err != nil {
+			t.Fatal(err)
+		}
+		w.AddEntities(cube)
+	})
+	rendertest.Golden(t, "gltf", img, rendertest.Options{MaxDiff: 0.001})
+}
+
+func TestGoldenCube(t *testing.T) {
+	img := rendertest.Render(t, 128, 128, func(w *ecs.World, rs *rebound.RenderSystem) {
+		rs.Camera.MoveTo(0, 0, 8)
+
+		skybox, err := rebound.NewSkybox(skyboxFaces)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rs.Skybox = skybox
+
+		texture := rebound.CurrentBackend().CreateTexture(checkerboard(64, 16))
+		cube := newCube()
+		cube.Material = &rebound.Material{PBRMetallicRoughness: rebound.PBRMetallicRoughness{BaseColorTexture: &texture}}
+		rebound.LoadMesh(cube)
+
+		transform := rebound.NewTransform()
+		transform.SetEuler([3]float32{30, 45, 0})
+		w.NewEntity(transform, &rebound.RenderComponent{Mesh: cube})
+	})
+	rendertest.Golden(t, "cube", img, rendertest.Options{MaxDiff: 0.001})
+}
+
+func TestGoldenPBR(t *testing.T) {
+	img := rendertest.Render(t, 192, 96, func(w *ecs.World, rs *rebound.RenderSystem) {
+		rs.Camera.MoveTo(0, 0, 8)
+		rs.Camera.Projection = rebound.NewProjectionMatrix(rs.Camera.FOV, 2, rs.Camera.NearPlane, rs.Camera.FarPlane)
+		ps := usePBR(t, rs)
+		ps.PointLights = []rebound.PointLight{{
+			Light:     rebound.Light{Position: [3]float32{0, 3, 4}, Colour: [3]float32{20, 20, 20}},
+			Constant:  1,
+			Linear:    0.09,
+			Quadratic: 0.032,
+		}}
+
+		// a rough plastic, a polished metal and a metal with rough squares, glowing faintly
+		texture := rebound.CurrentBackend().CreateTexture(checkerboard(64, 16))
+		materials := []*rebound.Material{
+			{PBRMetallicRoughness: rebound.PBRMetallicRoughness{BaseColor: [4]float32{1, 0.3, 0.1, 1}, RoughnessFactor: 0.8}},
+			{PBRMetallicRoughness: rebound.PBRMetallicRoughness{BaseColor: [4]float32{1, 0.8, 0.4, 1}, MetallicFactor: 1, RoughnessFactor: 0.2}},
+			{
+				EmissiveFactor: [3]float32{0, 0.05, 0.1},
+				PBRMetallicRoughness: rebound.PBRMetallicRoughness{
+					BaseColor: [4]float32{0.9, 0.9, 0.9, 1}, MetallicFactor: 1, RoughnessFactor: 1, MetallicRoughnessTexture: &texture,
+				},
+			},
+		}
+		for i, m := range materials {
+			cube := newCube()
+			cube.Material = m
+			rebound.LoadMesh(cube)
+
+			transform := rebound.NewTransform()
+			transform.Position = [3]float32{float32(i-1) * 3, 0, 0}
+			transform.SetEuler([3]float32{30, 45, 0})
+			w.NewEntity(transform, &rebound.RenderComponent{Mesh: cube})
+		}
+	})
+	rendertest.Golden(t, "pbr", img, rendertest.Options{MaxDiff: 0.001})
+}
+
+// usePBR replaces the shader of the RenderSystem with a PBRShader
+func usePBR(t *testing.T, rs *rebound.RenderSystem) *rebound.PBRShader {
+	t.Helper()
+	ps, err := rebound.NewPBRShader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs.Shader = ps
+	return ps
+}
+
+// checkerboard returns an image of size by size pixels with alternating orange and white squares of cell pixels
+func checkerboard(size, cell int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			c := color.RGBA{255, 255, 255, 255}
+			if (x/cell+y/cell)%2 == 0 {
+				c = color.RGBA{255, 128, 0, 255}
+			}
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+// newCube returns a cube of 2 by 2 by 2 with a normal and texture coordinates for each face
+func newCube() *rebound.Mesh {
+	faces := [6][3][3]float32{
+		// normal, right and up of each face
+		{{0, 0, 1}, {1, 0, 0}, {0, 1, 0}},
+		{{0, 0, -1}, {-1, 0, 0}, {0, 1, 0}},
+		{{1, 0, 0}, {0, 0, -1}, {0, 1, 0}},
+		{{-1, 0, 0}, {0, 0, 1}, {0, 1, 0}},
+		{{0, 1, 0}, {1, 0, 0}, {0, 0, -1}},
+		{{0, -1, 0}, {1, 0, 0}, {0, 0, 1}},
+	}
+	corners := [4][2]float32{{-1, -1}, {1, -1}, {1, 1}, {-1, 1}}
+
+	var positions, normals, uvs []float32
+	var indices []uint32
+	for i, f := range faces {
+		n, r, u := f[0], f[1], f[2]
+		for _, c := range corners {
+			for k := 0; k < 3; k++ {
+				positions = append(positions, n[k]+r[k]*c[0]+u[k]*c[1])
+			}
+			normals = append(normals, n[:]...)
+			uvs = append(uvs, (c[0]+1)/2, (1-c[1])/2)
+		}
+		base := uint32(i * 4)
+		indices = append(indices, base, base+1, base+2, base+2, base+3, base)
+	}
+
+	return &rebound.Mesh{
+		Indices: indices,
+		Attributes: []rebound.Attribute{
+			{Type: rebound.POSITION, Size: 3, Data: positions},
+			{Type: rebound.NORMALS, Size: 3, Data: normals},
+			{Type: rebound.TEXCOORDS0, Size: 2, Data: uvs},
+		},
+	}
+}
@@ -1,9 +1,14 @@
 package importers
 
 import (
+	"fmt"
 	"path"
+	"strconv"
+	"strings"
+	"sync"
 	"unsafe"
 
+	"github.com/go-gl/mathgl/mgl32"
 	"github.com/luukdegram/rebound"
 	"github.com/luukdegram/rebound/ecs"
 
@@ -12,22 +17,173 @@ import (
 
 // GLTFImporter loads a gltf file into Rebound to create a visual object.
 type GLTFImporter struct {
-	dir string
-	doc *gltf.Document
+	file string
+	dir  string
+	doc  *gltf.Document
 }
 
-// Import loads a GLTF file into a Scene.
-func (l *GLTFImporter) Import(file string) (*ecs.Entity, error) {
-	doc, err := gltf.Open(file)
+var (
+	prefabs      = make(map[string]*cachedPrefab)
+	prefabsMutex sync.Mutex
+
+	// documents holds the parsed files whose meshes are loaded one at a time by loadMesh
+	documents      = make(map[string]*document)
+	documentsMutex sync.Mutex
+)
+
+// document is a parsed GLTF file and the indices of the meshes loadMesh built from it
+type document struct {
+	doc   *gltf.Document
+	built map[int]bool
+}
+
+// cachedPrefab is a prefab returned by Prefab and the meshes it retains
+type cachedPrefab struct {
+	prefab *ecs.Prefab
+	meshes []*rebound.Mesh
+}
+
+func init() {
+	rebound.RegisterMeshLoader(".gltf", loadMesh)
+	rebound.RegisterMeshLoader(".glb", loadMesh)
+	rebound.OnMeshUnload(evictDocument)
+}
+
+// evictDocument removes the parsed file of the mesh from the cache once the mesh is unloaded
+func evictDocument(m *rebound.Mesh) {
+	if i := strings.LastIndex(m.Source, "#"); i >= 0 {
+		forgetDocument(m.Source[:i])
+	}
+}
+
+// forgetDocument removes the parsed file from the cache
+func forgetDocument(file string) {
+	documentsMutex.Lock()
+	delete(documents, file)
+	documentsMutex.Unlock()
+}
+
+// loadMesh loads a single mesh of a GLTF file, the source holds the file and the index of the mesh as file#index
+func loadMesh(source string) (*rebound.Mesh, error) {
+	i := strings.LastIndex(source, "#")
+	if i < 0 {
+		return nil, fmt.Errorf("importers: missing mesh index in %s", source)
+	}
+	index, err := strconv.Atoi(source[i+1:])
+	if err != nil {
+		return nil, fmt.Errorf("importers: invalid mesh index in %s: %w", source, err)
+	}
+
+	file := source[:i]
+	l := &GLTFImporter{}
+	if err := l.open(file); err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(l.doc.Meshes) {
+		return nil, fmt.Errorf("importers: mesh index out of range in %s", source)
+	}
+	mesh, err := l.buildMesh(uint32(index))
 	if err != nil {
 		return nil, err
 	}
-	l.doc = doc
 
-	dir := path.Dir(file)
+	// the parsed file is no longer needed once every mesh of the file is built
+	documentsMutex.Lock()
+	if d, exists := documents[file]; exists {
+		d.built[index] = true
+		if len(d.built) == len(d.doc.Meshes) {
+			delete(documents, file)
+		}
+	}
+	documentsMutex.Unlock()
+	return mesh, nil
+}
+
+// open reads the GLTF file, a file is parsed once and shared until its meshes are built or unloaded
+func (l *GLTFImporter) open(file string) error {
+	documentsMutex.Lock()
+	defer documentsMutex.Unlock()
+
+	d, exists := documents[file]
+	if !exists {
+		doc, err := gltf.Open(file)
+		if err != nil {
+			return err
+		}
+		d = &document{doc: doc, built: make(map[int]bool)}
+		documents[file] = d
+	}
+	l.doc = d.doc
+	l.file = file
+	l.dir = path.Dir(file)
+	return nil
+}
+
+// Prefab imports a GLTF file once and returns it as an [ecs.Prefab], whose instances share the meshes and materials of the file.
+// Calling Prefab again with the same file returns the same Prefab without reading the file again.
+// The Prefab retains its meshes, see [rebound.RetainMesh], so they stay loaded while a [rebound.RenderSystem] releases meshes,
+// until the Prefab is released using [GLTFImporter.ReleasePrefab]
+func (l *GLTFImporter) Prefab(file string) (*ecs.Prefab, error) {
+	prefabsMutex.Lock()
+	defer prefabsMutex.Unlock()
 
-	scene := ecs.NewEntity()
-	l.dir = dir
+	if p, exists := prefabs[file]; exists {
+		return p.prefab, nil
+	}
+
+	scene, err := l.Import(file)
+	if err != nil {
+		return nil, err
+	}
+	p := &cachedPrefab{prefab: ecs.NewPrefab(scene), meshes: sceneMeshes(scene, nil)}
+	for _, mesh := range p.meshes {
+		rebound.RetainMesh(mesh)
+	}
+	scene.Destroy()
+
+	prefabs[file] = p
+	return p.prefab, nil
+}
+
+// ReleasePrefab removes the Prefab of the file from the cache and releases its meshes.
+// Meshes that are not used by any instance anymore are unloaded, the next call to Prefab imports the file again
+func (l *GLTFImporter) ReleasePrefab(file string) {
+	prefabsMutex.Lock()
+	p, exists := prefabs[file]
+	delete(prefabs, file)
+	prefabsMutex.Unlock()
+
+	if !exists {
+		return
+	}
+	for _, mesh := range p.meshes {
+		if rebound.ReleaseMesh(mesh) {
+			rebound.UnloadMesh(mesh)
+		}
+	}
+}
+
+// sceneMeshes appends the meshes of the entity and its children to meshes
+func sceneMeshes(e *ecs.Entity, meshes []*rebound.Mesh) []*rebound.Mesh {
+	if rc, ok := ecs.Get[*rebound.RenderComponent](e); ok && rc.Mesh != nil {
+		meshes = append(meshes, rc.Mesh)
+	}
+	for _, child := range e.Children() {
+		meshes = sceneMeshes(child, meshes)
+	}
+	return meshes
+}
+
+// Import loads a GLTF file into a Scene.
+func (l *GLTFImporter) Import(file string) (*ecs.Entity, error) {
+	if err := l.open(file); err != nil {
+		return nil, err
+	}
+	// every mesh of the scene is built by Import, so the parsed file is not kept
+	defer forgetDocument(file)
+	doc := l.doc
+
+	scene := ecs.NewEntity(rebound.NewTransform())
 
 	var index int
 	if doc.Scene == nil {
@@ -37,8 +193,8 @@ func (l *GLTFImporter) Import(file string) (*ecs.Entity, error) {
 	}
 
 	for _, rootNodeIndex := range doc.Scenes[index].Nodes {
-		var node *ecs.Entity
-		if node, err = l.buildNode(doc.Nodes[rootNodeIndex]); err != nil {
+		node, err := l.buildNode(doc.Nodes[rootNodeIndex])
+		if err != nil {
 			return nil, err
 		}
 		scene.AddChild(node)
@@ -50,12 +206,22 @@ func (l *GLTFImporter) Import(file string) (*ecs.Entity, error) {
 func (l *GLTFImporter) buildNode(n *gltf.Node) (*ecs.Entity, error) {
 	var err error
 	// Create a node with defailt values if no values exist
-	node := ecs.NewEntity()
+	transform := rebound.NewTransform()
+	// the matrix is the identity when the node uses translation, rotation and scale instead
+	if n.Matrix != emptyMatrix && n.Matrix != gltf.DefaultMatrix {
+		transform.SetLocal(toFloat32Array(n.Matrix))
+	} else {
+		rot := n.RotationOrDefault()
+		transform.Position = toFloat32Vec3(n.TranslationOrDefault())
+		transform.Rotation = mgl32.Quat{W: float32(rot[3]), V: mgl32.Vec3{float32(rot[0]), float32(rot[1]), float32(rot[2])}}
+		transform.Scale = toFloat32Vec3(n.ScaleOrDefault())
+	}
+	node := ecs.NewEntity(transform)
 
 	// Build a mesh
 	if n.Mesh != nil {
 		var mesh *rebound.Mesh
-		if mesh, err = l.buildMesh(l.doc.Meshes[*n.Mesh]); err != nil {
+		if mesh, err = l.buildMesh(*n.Mesh); err != nil {
 			return nil, err
 		}
 		node.AddComponent(&rebound.RenderComponent{
@@ -80,11 +246,13 @@ func (l *GLTFImporter) buildNode(n *gltf.Node) (*ecs.Entity, error) {
 	return node, nil
 }
 
-func (l *GLTFImporter) buildMesh(m *gltf.Mesh) (*rebound.Mesh, error) {
+func (l *GLTFImporter) buildMesh(index uint32) (*rebound.Mesh, error) {
 	var err error
+	m := l.doc.Meshes[index]
 	mesh := &rebound.Mesh{
 		Attributes: make([]rebound.Attribute, 0),
 		Indices:    make([]uint32, 0),
+		Source:     fmt.Sprintf("%s#%d", l.file, index),
 	}
 
 	for _, primitive := range m.Primitives {
@@ -118,35 +286,79 @@ func (l *GLTFImporter) buildMesh(m *gltf.Mesh) (*rebound.Mesh, error) {
 }
 
 func (l *GLTFImporter) buildMaterial(m *gltf.Material) (*rebound.Material, error) {
+	var err error
 	material := &rebound.Material{
 		Transparent: m.DoubleSided,
 	}
-	if m.PBRMetallicRoughness.BaseColorTexture != nil {
-		texture := l.doc.Textures[m.PBRMetallicRoughness.BaseColorTexture.Index]
-		texID, err := rebound.LoadTexture(l.dir + "/" + l.doc.Images[*texture.Source].URI)
-		if err != nil {
-			return nil, err
+	for i, f := range m.EmissiveFactor {
+		material.EmissiveFactor[i] = float32(f)
+	}
+
+	// glTF defaults to a white, fully metallic and rough material
+	material.BaseColor = [4]float32{1, 1, 1, 1}
+	material.MetallicFactor, material.RoughnessFactor = 1, 1
+	if pbr := m.PBRMetallicRoughness; pbr != nil {
+		rgba := pbr.BaseColorFactorOrDefault()
+		material.BaseColor = [4]float32{float32(rgba.R), float32(rgba.G), float32(rgba.B), float32(rgba.A)}
+		material.MetallicFactor = float32(pbr.MetallicFactorOrDefault())
+		material.RoughnessFactor = float32(pbr.RoughnessFactorOrDefault())
+
+		if pbr.BaseColorTexture != nil {
+			if material.BaseColorTexture, err = l.loadTexture(pbr.BaseColorTexture.Index); err != nil {
+				return nil, err
+			}
 		}
-		material.BaseColorTexture = &texID
-
-		/*
-			if texture.Sampler != nil {
-				s := l.Doc.Samplers[*texture.Sampler]
-				sampler := &rebound.Sampler{
-					MagFilter: int32(s.MagFilter),
-					MinFilter: uint16(s.MinFilter),
-					WrapS:     uint16(s.WrapS),
-					WrapT:     uint16(s.WrapT),
-				}
+		if pbr.MetallicRoughnessTexture != nil {
+			if material.MetallicRoughnessTexture, err = l.loadTexture(pbr.MetallicRoughnessTexture.Index); err != nil {
+				return nil, err
 			}
-		*/
+		}
+	}
+
+	if m.NormalTexture != nil && m.NormalTexture.Index != nil {
+		if material.NormalTexture, err = l.loadTexture(*m.NormalTexture.Index); err != nil {
+			return nil, err
+		}
+	}
+	if m.OcclusionTexture != nil && m.OcclusionTexture.Index != nil {
+		if material.OcclusionTexture, err = l.loadTexture(*m.OcclusionTexture.Index); err != nil {
+			return nil, err
+		}
+	}
+	if m.EmissiveTexture != nil {
+		if material.EmmisiveTexture, err = l.loadTexture(m.EmissiveTexture.Index); err != nil {
+			return nil, err
+		}
 	}
-	rgba := m.PBRMetallicRoughness.BaseColorFactor
-	material.BaseColor = [4]float32{float32(rgba.A), float32(rgba.R), float32(rgba.G), float32(rgba.B)}
 
 	return material, nil
 }
 
+// loadTexture loads the image of the texture, returning the id of the texture
+func (l *GLTFImporter) loadTexture(index uint32) (*uint32, error) {
+	texture := l.doc.Textures[index]
+	if texture.Source == nil {
+		return nil, fmt.Errorf("importers: texture %d has no image", index)
+	}
+	texID, err := rebound.LoadTexture(l.dir + "/" + l.doc.Images[*texture.Source].URI)
+	if err != nil {
+		return nil, err
+	}
+
+	/*
+		if texture.Sampler != nil {
+			s := l.Doc.Samplers[*texture.Sampler]
+			sampler := &rebound.Sampler{
+				MagFilter: int32(s.MagFilter),
+				MinFilter: uint16(s.MinFilter),
+				WrapS:     uint16(s.WrapS),
+				WrapT:     uint16(s.WrapT),
+			}
+		}
+	*/
+	return &texID, nil
+}
+
 // loadAccessorF32 loads the float32 values from the buffer
 func (l *GLTFImporter) loadAccessorF32(index int) []float32 {
 	accessor := l.doc.Accessors[index]
@@ -167,7 +379,7 @@ func (l *GLTFImporter) loadAccessorF32(index int) []float32 {
 		}
 		break
 	default:
-		out = (*[1 << 30]float32)(unsafe.Pointer(&data[0]))[:count]
+		out = unsafe.Slice((*float32)(unsafe.Pointer(&data[0])), count)
 		break
 	}
 	return out
@@ -193,7 +405,7 @@ func (l *GLTFImporter) loadAccessorU32(index int) []uint32 {
 		}
 		break
 	default:
-		out = (*[1 << 30]uint32)(unsafe.Pointer(&data[0]))[:count]
+		out = unsafe.Slice((*uint32)(unsafe.Pointer(&data[0])), count)
 		break
 	}
 	return out
@@ -235,6 +447,10 @@ var attTypes = map[string]rebound.AttributeType{
 
 var emptyMatrix = [16]float64{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
 
+func toFloat32Vec3(input [3]float64) [3]float32 {
+	return [3]float32{float32(input[0]), float32(input[1]), float32(input[2])}
+}
+
 func toFloat32Array(input [16]float64) [16]float32 {
 	var result [16]float32
 
@@ -16,6 +16,16 @@ type Display interface {
 	Close()
 	Update()
 	GetSize() Size
+	SetEventHandler(h EventHandler)
+}
+
+//EventHandler receives the events of a window, such as key presses or the window being resized
+type EventHandler interface {
+	KeyEvent(key input.Key, pressed bool)
+	MouseButtonEvent(button input.MouseButton, pressed bool)
+	ResizeEvent(size Size)
+	FocusEvent(focused bool)
+	CloseEvent()
 }
 
 //Size holds width and height of a window
@@ -31,8 +41,9 @@ func Default() Display {
 
 //GLFWDisplay handles the GLFW window
 type GLFWDisplay struct {
-	w    *glfw.Window
-	size Size
+	w       *glfw.Window
+	size    Size
+	handler EventHandler
 }
 
 //NewGLFWDisplay creates a new GLFWManager struct
@@ -86,6 +97,8 @@ func (g *GLFWDisplay) Init(width int, height int, title string) error {
 		g.registerMouseButtonHandler()
 		// Set cursor position handler
 		g.registerCurserPosHandler()
+		// Set window handlers
+		g.registerWindowHandlers()
 
 		return nil
 	})
@@ -142,23 +155,52 @@ type ScrollCallback func(x, y float64)
 //RegisterKeyboardHandler registers a callback action to a certain key
 func (g *GLFWDisplay) registerKeyboardHandler() {
 	g.w.SetKeyCallback(func(window *glfw.Window, glfwKey glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+		key, known := glfwKeyToKey[glfwKey]
 		if action == glfw.Press {
-			input.Keys.Set(glfwKeyToKey[glfwKey], true)
+			input.Keys.Set(key, true)
 		}
 
 		if action == glfw.Release {
-			input.Keys.Set(glfwKeyToKey[glfwKey], false)
+			input.Keys.Set(key, false)
+		}
+
+		if known && action != glfw.Repeat && g.handler != nil {
+			g.handler.KeyEvent(key, action == glfw.Press)
 		}
 	})
 }
 
 func (g *GLFWDisplay) registerMouseButtonHandler() {
 	g.w.SetMouseButtonCallback(func(w *glfw.Window, button glfw.MouseButton, action glfw.Action, mod glfw.ModifierKey) {
+		mb, known := glfwMouseButtonToMouseButton[button]
 		if action == glfw.Press {
-			input.Mouse.Set(glfwMouseButtonToMouseButton[button], true)
+			input.Mouse.Set(mb, true)
 		}
 		if action == glfw.Release {
-			input.Mouse.Set(glfwMouseButtonToMouseButton[button], false)
+			input.Mouse.Set(mb, false)
+		}
+
+		if known && g.handler != nil {
+			g.handler.MouseButtonEvent(mb, action == glfw.Press)
+		}
+	})
+}
+
+func (g *GLFWDisplay) registerWindowHandlers() {
+	g.w.SetSizeCallback(func(w *glfw.Window, width, height int) {
+		g.size = Size{Width: width, Height: height}
+		if g.handler != nil {
+			g.handler.ResizeEvent(g.size)
+		}
+	})
+	g.w.SetFocusCallback(func(w *glfw.Window, focused bool) {
+		if g.handler != nil {
+			g.handler.FocusEvent(focused)
+		}
+	})
+	g.w.SetCloseCallback(func(w *glfw.Window) {
+		if g.handler != nil {
+			g.handler.CloseEvent()
 		}
 	})
 }
@@ -202,3 +244,9 @@ func (g *GLFWDisplay) Update() {
 func (g *GLFWDisplay) GetSize() Size {
 	return g.size
 }
+
+//SetEventHandler sets the handler that receives the events of the window.
+//The handler is called from the main thread while polling events
+func (g *GLFWDisplay) SetEventHandler(h EventHandler) {
+	g.handler = h
+}
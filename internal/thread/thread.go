@@ -3,6 +3,9 @@ package thread
 import (
 	"context"
 	"runtime"
+	"sync/atomic"
+
+	"github.com/luukdegram/rebound/profile"
 )
 
 func init() {
@@ -12,10 +15,19 @@ func init() {
 var (
 	// channel that runs on the main thread. Every call will enter this 'queue'.
 	queue = make(chan func())
+
+	// running is true while Run processes the queue
+	running atomic.Bool
+
+	// inline runs the calls on the calling goroutine while Run is not active
+	inline atomic.Bool
 )
 
 // Run initializes the LockOSThread function and lives until closure of the application.
 func Run(context context.Context) {
+	Start()
+	defer running.Store(false)
+
 	var done = false
 	for !done {
 		select {
@@ -27,10 +39,42 @@ func Run(context context.Context) {
 	}
 }
 
+// Start marks the mainthread as running before Run is called, so calls made by goroutines started in the meantime
+// are queued for the mainthread instead of running inline
+func Start() {
+	running.Store(true)
+}
+
+// SetInline makes calls run directly on the calling goroutine while Run is not active.
+// This is only meant for rendering without a window, i.e. by the software backend, as GLFW and OpenGL require the mainthread.
+// Without it, calls wait until Run processes them on the mainthread
+func SetInline(enabled bool) {
+	inline.Store(enabled)
+}
+
+// runInline returns whether a call runs on the calling goroutine, see SetInline
+func runInline() bool {
+	return !running.Load() && inline.Load()
+}
+
+// Running returns true while Run processes the calls on the mainthread
+func Running() bool {
+	return running.Load()
+}
+
 // Call will ensure the the function provided will be run on the mainthread
+// When Run is not active the call waits for Run, unless SetInline is enabled, then the function is run directly on the calling goroutine
+// The time spent waiting for the mainthread is recorded by the default [profile.Profiler] as "thread.Wait"
 func Call(f func()) {
+	if runInline() {
+		f()
+		return
+	}
+
 	done := make(chan bool, 1)
+	wait := profile.Default().Begin("thread.Wait", "thread")
 	queue <- func() {
+		wait.End()
 		f()
 		done <- true
 	}
@@ -39,6 +83,10 @@ func Call(f func()) {
 
 // CallVal will alow you to run a function on the mainthread and return a value
 func CallVal(f func() interface{}) interface{} {
+	if runInline() {
+		return f()
+	}
+
 	val := make(chan interface{}, 1)
 	queue <- func() {
 		val <- f()
@@ -48,6 +96,10 @@ func CallVal(f func() interface{}) interface{} {
 
 // CallErr is a helper function to call a function on the mainthread that could result in an error
 func CallErr(f func() error) error {
+	if runInline() {
+		return f()
+	}
+
 	err := make(chan error, 1)
 	queue <- func() {
 		err <- f()
@@ -0,0 +1,55 @@
+package thread
+
+import (
+	"context"
+	"runtime"
+	"testing"
+)
+
+func TestCallInline(t *testing.T) {
+	SetInline(true)
+	defer SetInline(false)
+
+	called := false
+	Call(func() { called = true })
+	if !called {
+		t.Errorf("Call failed. Expected the function to run without Run")
+	}
+
+	if v := CallVal(func() interface{} { return 1 }); v != 1 {
+		t.Errorf("CallVal failed. Expected %v, but got %v", 1, v)
+	}
+}
+
+func TestCallRunning(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		for !Running() {
+			runtime.Gosched()
+		}
+		Call(func() {})
+		cancel()
+		close(stopped)
+	}()
+
+	Run(ctx)
+	<-stopped
+	if Running() {
+		t.Errorf("Run failed. Expected Running to be false after Run returned")
+	}
+}
+
+func TestCallWaitsForRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	onMain := make(chan bool, 1)
+	go func() {
+		Call(func() { onMain <- Running() })
+		cancel()
+	}()
+
+	Run(ctx)
+	if !<-onMain {
+		t.Errorf("Call failed. Expected the function to wait for Run")
+	}
+}
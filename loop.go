@@ -0,0 +1,79 @@
+package rebound
+
+import (
+	"math"
+	"sync"
+
+	"github.com/luukdegram/rebound/ecs"
+)
+
+const defaultMaxCatchUp = 5
+
+// Clock controls the speed at which time passes for the systems, it can be changed while the application runs
+type Clock struct {
+	m     sync.RWMutex
+	scale float64
+}
+
+// NewClock returns a Clock that runs at normal speed
+func NewClock() *Clock {
+	return &Clock{scale: 1}
+}
+
+// SetTimeScale changes the speed of time, i.e. 0.5 for slow motion or 0 to pause the systems
+func (c *Clock) SetTimeScale(scale float64) {
+	c.m.Lock()
+	c.scale = math.Max(scale, 0)
+	c.m.Unlock()
+}
+
+// TimeScale returns the speed of time, 1 being normal speed
+func (c *Clock) TimeScale() float64 {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	return c.scale
+}
+
+// loop advances the world every frame, either with a variable time step or using fixed updates
+type loop struct {
+	world       *ecs.World
+	step        float64
+	maxCatchUp  int
+	accumulator float64
+}
+
+// newLoop creates a loop that runs rate fixed updates per second, or uses a variable time step when the rate is 0
+func newLoop(world *ecs.World, rate float64, maxCatchUp int) *loop {
+	l := &loop{world: world, maxCatchUp: maxCatchUp}
+	if rate > 0 {
+		l.step = 1 / rate
+	}
+	if l.maxCatchUp <= 0 {
+		l.maxCatchUp = defaultMaxCatchUp
+	}
+	return l
+}
+
+// advance updates the world with the time in seconds that passed since the previous frame.
+// With a fixed time step, the world runs as many fixed updates as fit in the passed time, up to the max catch-up,
+// followed by a render update with the remaining fraction of a step as alpha.
+// Time that does not fit within the max catch-up is dropped, which slows the simulation down instead of falling further behind
+func (l *loop) advance(frame float64) error {
+	if l.step == 0 {
+		return l.world.Update(frame)
+	}
+
+	l.accumulator += frame
+	for steps := 0; l.accumulator >= l.step; steps++ {
+		if steps == l.maxCatchUp {
+			l.accumulator = math.Mod(l.accumulator, l.step)
+			break
+		}
+		if err := l.world.FixedUpdate(l.step); err != nil {
+			return err
+		}
+		l.accumulator -= l.step
+	}
+
+	return l.world.RenderUpdate(frame, l.accumulator/l.step)
+}
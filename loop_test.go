@@ -0,0 +1,74 @@
+package rebound
+
+import (
+	"testing"
+
+	"github.com/luukdegram/rebound/ecs"
+)
+
+type countingSystem struct {
+	ecs.BaseSystem
+	stage   ecs.Stage
+	updates int
+	dt      float64
+	alpha   float64
+}
+
+func (c *countingSystem) Update(dt float64) {
+	c.updates++
+	c.dt = dt
+}
+func (c *countingSystem) Name() string              { return c.stage.String() }
+func (c *countingSystem) Stage() ecs.Stage          { return c.stage }
+func (c *countingSystem) Interpolate(alpha float64) { c.alpha = alpha }
+
+func newLoopWorld(t *testing.T) (*ecs.World, *countingSystem, *countingSystem) {
+	w := ecs.NewWorld()
+	fixed := &countingSystem{BaseSystem: ecs.NewBaseSystem(), stage: ecs.StageUpdate}
+	render := &countingSystem{BaseSystem: ecs.NewBaseSystem(), stage: ecs.StageRender}
+	if err := w.AddSystems(fixed, render); err != nil {
+		t.Fatal(err)
+	}
+	return w, fixed, render
+}
+
+func TestLoopFixedStep(t *testing.T) {
+	w, fixed, render := newLoopWorld(t)
+	l := newLoop(w, 4, 0)
+
+	l.advance(0.625)
+	if fixed.updates != 2 || fixed.dt != 0.25 {
+		t.Errorf("advance failed. Expected 2 fixed updates of 0.25, but got %d of %v", fixed.updates, fixed.dt)
+	}
+	if render.updates != 1 || render.alpha != 0.5 {
+		t.Errorf("advance failed. Expected 1 render update with alpha 0.5, but got %d with %v", render.updates, render.alpha)
+	}
+
+	l.advance(0.125)
+	if fixed.updates != 3 || render.updates != 2 || render.alpha != 0 {
+		t.Errorf("advance failed. Expected the accumulated time to run a fixed update, but got %d with alpha %v", fixed.updates, render.alpha)
+	}
+}
+
+func TestLoopMaxCatchUp(t *testing.T) {
+	w, fixed, render := newLoopWorld(t)
+	l := newLoop(w, 4, 3)
+
+	l.advance(2.125)
+	if fixed.updates != 3 || render.updates != 1 {
+		t.Errorf("advance failed. Expected 3 fixed updates, but got %d", fixed.updates)
+	}
+	if l.accumulator >= l.step {
+		t.Errorf("advance failed. Expected the remaining time to be dropped, but %v is left", l.accumulator)
+	}
+}
+
+func TestLoopVariableStep(t *testing.T) {
+	w, fixed, render := newLoopWorld(t)
+	l := newLoop(w, 0, 0)
+
+	l.advance(0.016)
+	if fixed.updates != 1 || fixed.dt != 0.016 || render.updates != 1 || render.alpha != 1 {
+		t.Errorf("advance failed. Expected every system to update once with dt 0.016 and alpha 1")
+	}
+}
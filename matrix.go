@@ -1,17 +1,119 @@
 package rebound
 
-import "github.com/go-gl/mathgl/mgl32"
+import (
+	"math"
 
-//NewTransformationMatrix returns a new transformation matrix, it translates, rotates and scales.
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+//NewTransformationMatrix returns a new transformation matrix, it scales, rotates and translates.
+//The rotation holds the angles around the X, Y and Z axis in degrees, see [EulerToQuat]
 func NewTransformationMatrix(trans [3]float32, rot [3]float32, scale [3]float32) [16]float32 {
-	mat := mgl32.Ident4()
-	translation := mgl32.Translate3D(float32(trans[0]), float32(trans[1]), float32(trans[2]))
-	rotX := mgl32.HomogRotate3DX(mgl32.DegToRad(float32(rot[0])))
-	rotY := mgl32.HomogRotate3DY(mgl32.DegToRad(float32(rot[1])))
-	rotZ := mgl32.HomogRotate3DZ(mgl32.DegToRad(float32(rot[2])))
-	scaleMatrix := mgl32.Scale3D(float32(scale[0]), float32(scale[1]), float32(scale[2]))
+	return ComposeMatrix(trans, EulerToQuat(rot), scale)
+}
+
+//ComposeMatrix returns a transformation matrix that scales, rotates and translates, in that order
+func ComposeMatrix(trans [3]float32, rot mgl32.Quat, scale [3]float32) [16]float32 {
+	r := rot.Normalize().Mat4()
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			r[col*4+row] *= scale[col]
+		}
+	}
+	r[12], r[13], r[14] = trans[0], trans[1], trans[2]
+	return r
+}
+
+//DecomposeMatrix splits a transformation matrix into its translation, rotation and scale.
+//A matrix that mirrors the object results in a negative scale on the X axis
+func DecomposeMatrix(m [16]float32) (trans [3]float32, rot mgl32.Quat, scale [3]float32) {
+	mat := mgl32.Mat4(m)
+	trans = [3]float32{m[12], m[13], m[14]}
+	scale = [3]float32{
+		mat.Col(0).Vec3().Len(),
+		mat.Col(1).Vec3().Len(),
+		mat.Col(2).Vec3().Len(),
+	}
+	if mat.Mat3().Det() < 0 {
+		scale[0] = -scale[0]
+	}
+
+	var r mgl32.Mat4
+	for col := 0; col < 3; col++ {
+		for row := 0; row < 3; row++ {
+			if scale[col] != 0 {
+				r[col*4+row] = m[col*4+row] / scale[col]
+			}
+		}
+	}
+	r[15] = 1
+	rot = mgl32.Mat4ToQuat(r).Normalize()
+	return trans, rot, scale
+}
+
+//LookAt returns the rotation that points the front of an object, which faces -Z, from eye towards target.
+//The up vector is used to keep the object upright
+func LookAt(eye, target, up [3]float32) mgl32.Quat {
+	forward := mgl32.Vec3(target).Sub(eye)
+	if forward.Len() == 0 {
+		return mgl32.QuatIdent()
+	}
+	forward = forward.Normalize()
+
+	right := forward.Cross(up)
+	if right.Len() < 1e-6 {
+		// up is parallel to the view direction, any perpendicular vector will do
+		right = forward.Cross(mgl32.Vec3{1, 0, 0})
+		if right.Len() < 1e-6 {
+			right = forward.Cross(mgl32.Vec3{0, 0, 1})
+		}
+	}
+	right = right.Normalize()
+	newUp := right.Cross(forward)
+
+	r := mgl32.Ident4()
+	r.SetCol(0, right.Vec4(0))
+	r.SetCol(1, newUp.Vec4(0))
+	r.SetCol(2, forward.Mul(-1).Vec4(0))
+	return mgl32.Mat4ToQuat(r).Normalize()
+}
+
+//Slerp interpolates between 2 rotations at a constant speed, always taking the shortest path
+func Slerp(from, to mgl32.Quat, t float32) mgl32.Quat {
+	if from.Dot(to) < 0 {
+		to = to.Scale(-1)
+	}
+	return mgl32.QuatSlerp(from, to, t).Normalize()
+}
+
+//EulerToQuat converts angles around the X, Y and Z axis in degrees to a rotation.
+//The result equals rotating around Z first, followed by Y and X
+func EulerToQuat(rot [3]float32) mgl32.Quat {
+	x := mgl32.QuatRotate(mgl32.DegToRad(rot[0]), mgl32.Vec3{1, 0, 0})
+	y := mgl32.QuatRotate(mgl32.DegToRad(rot[1]), mgl32.Vec3{0, 1, 0})
+	z := mgl32.QuatRotate(mgl32.DegToRad(rot[2]), mgl32.Vec3{0, 0, 1})
+	return x.Mul(y).Mul(z)
+}
+
+//QuatToEuler converts a rotation to angles around the X, Y and Z axis in degrees, the inverse of [EulerToQuat].
+//When the Y angle is at +/-90 degrees, the rotation around Z is returned as part of the rotation around X
+func QuatToEuler(q mgl32.Quat) [3]float32 {
+	m := q.Normalize().Mat4()
+	y := math.Asin(float64(mgl32.Clamp(m[8], -1, 1)))
+
+	var x, z float64
+	if math.Abs(float64(m[8])) < 0.9999 {
+		x = math.Atan2(float64(-m[9]), float64(m[10]))
+		z = math.Atan2(float64(-m[4]), float64(m[0]))
+	} else {
+		x = math.Atan2(float64(m[6]), float64(m[5]))
+	}
 
-	return mat.Add(translation).Mul4(rotX).Mul4(rotY).Mul4(rotZ).Mul4(scaleMatrix)
+	return [3]float32{
+		mgl32.RadToDeg(float32(x)),
+		mgl32.RadToDeg(float32(y)),
+		mgl32.RadToDeg(float32(z)),
+	}
 }
 
 //NewProjectionMatrix returns a new projection matrix
@@ -0,0 +1,142 @@
+package rebound
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestNewTransformationMatrix(t *testing.T) {
+	tests := []struct {
+		name   string
+		trans  [3]float32
+		rot    [3]float32
+		scale  [3]float32
+		expect mgl32.Mat4
+	}{
+		{"identity", [3]float32{0, 0, 0}, [3]float32{0, 0, 0}, [3]float32{1, 1, 1}, mgl32.Ident4()},
+		{"translation", [3]float32{1, 2, 3}, [3]float32{0, 0, 0}, [3]float32{1, 1, 1}, mgl32.Mat4{
+			1, 0, 0, 0,
+			0, 1, 0, 0,
+			0, 0, 1, 0,
+			1, 2, 3, 1,
+		}},
+		{"scale", [3]float32{0, 0, 0}, [3]float32{0, 0, 0}, [3]float32{2, 3, 4}, mgl32.Mat4{
+			2, 0, 0, 0,
+			0, 3, 0, 0,
+			0, 0, 4, 0,
+			0, 0, 0, 1,
+		}},
+		{"rotation Y", [3]float32{0, 0, 0}, [3]float32{0, 90, 0}, [3]float32{1, 1, 1}, mgl32.Mat4{
+			0, 0, -1, 0,
+			0, 1, 0, 0,
+			1, 0, 0, 0,
+			0, 0, 0, 1,
+		}},
+		{"TRS", [3]float32{5, 0, 0}, [3]float32{0, 0, 90}, [3]float32{2, 2, 2}, mgl32.Mat4{
+			0, 2, 0, 0,
+			-2, 0, 0, 0,
+			0, 0, 2, 0,
+			5, 0, 0, 1,
+		}},
+		{"euler XYZ", [3]float32{0, 0, 0}, [3]float32{90, 90, 0}, [3]float32{1, 1, 1},
+			mgl32.HomogRotate3DX(mgl32.DegToRad(90)).Mul4(mgl32.HomogRotate3DY(mgl32.DegToRad(90)))},
+	}
+
+	for _, test := range tests {
+		got := mgl32.Mat4(NewTransformationMatrix(test.trans, test.rot, test.scale))
+		if !got.ApproxFuncEqual(test.expect, near) {
+			t.Errorf("NewTransformationMatrix %s failed. Expected %v, but got %v", test.name, test.expect, got)
+		}
+	}
+}
+
+func TestDecomposeMatrix(t *testing.T) {
+	trans := [3]float32{1, -2, 3}
+	rot := EulerToQuat([3]float32{30, 45, 60})
+	scale := [3]float32{2, 0.5, 3}
+
+	gotTrans, gotRot, gotScale := DecomposeMatrix(ComposeMatrix(trans, rot, scale))
+	if !mgl32.Vec3(gotTrans).ApproxFuncEqual(trans, near) {
+		t.Errorf("DecomposeMatrix failed. Expected translation %v, but got %v", trans, gotTrans)
+	}
+	if !gotRot.OrientationEqualThreshold(rot, 1e-5) {
+		t.Errorf("DecomposeMatrix failed. Expected rotation %v, but got %v", rot, gotRot)
+	}
+	if !mgl32.Vec3(gotScale).ApproxFuncEqual(scale, near) {
+		t.Errorf("DecomposeMatrix failed. Expected scale %v, but got %v", scale, gotScale)
+	}
+
+	_, _, mirrored := DecomposeMatrix(ComposeMatrix(trans, rot, [3]float32{-1, 1, 1}))
+	if !mgl32.Vec3(mirrored).ApproxFuncEqual(mgl32.Vec3{-1, 1, 1}, near) {
+		t.Errorf("DecomposeMatrix failed. Expected scale %v, but got %v", [3]float32{-1, 1, 1}, mirrored)
+	}
+}
+
+func TestEuler(t *testing.T) {
+	tests := [][3]float32{
+		{0, 0, 0},
+		{90, 0, 0},
+		{10, 20, 30},
+		{-45, 60, 120},
+	}
+
+	for _, rot := range tests {
+		got := QuatToEuler(EulerToQuat(rot))
+		if !mgl32.Vec3(got).ApproxFuncEqual(rot, near) {
+			t.Errorf("QuatToEuler failed. Expected %v, but got %v", rot, got)
+		}
+	}
+
+	// At 90 degrees around Y the rotations around X and Z share an axis
+	q := EulerToQuat([3]float32{20, 90, 30})
+	if got := EulerToQuat(QuatToEuler(q)); !got.OrientationEqualThreshold(q, 1e-4) {
+		t.Errorf("QuatToEuler failed. Expected %v, but got %v", q, got)
+	}
+}
+
+func TestSlerp(t *testing.T) {
+	from := mgl32.QuatIdent()
+	to := EulerToQuat([3]float32{0, 90, 0})
+
+	expect := EulerToQuat([3]float32{0, 45, 0})
+	if got := Slerp(from, to, 0.5); !got.OrientationEqualThreshold(expect, 1e-5) {
+		t.Errorf("Slerp failed. Expected %v, but got %v", expect, got)
+	}
+
+	// The negated quaternion represents the same rotation and must take the same path
+	if got := Slerp(from, to.Scale(-1), 0.5); !got.OrientationEqualThreshold(expect, 1e-5) {
+		t.Errorf("Slerp failed. Expected shortest path %v, but got %v", expect, got)
+	}
+}
+
+func TestLookAt(t *testing.T) {
+	tests := []struct {
+		eye, target, up [3]float32
+	}{
+		{[3]float32{0, 0, 0}, [3]float32{0, 0, -1}, [3]float32{0, 1, 0}},
+		{[3]float32{0, 0, 0}, [3]float32{1, 0, 0}, [3]float32{0, 1, 0}},
+		{[3]float32{1, 2, 3}, [3]float32{4, -2, 0}, [3]float32{0, 1, 0}},
+		{[3]float32{0, 0, 0}, [3]float32{0, 5, 0}, [3]float32{0, 1, 0}},
+	}
+
+	for _, test := range tests {
+		q := LookAt(test.eye, test.target, test.up)
+		forward := q.Rotate(mgl32.Vec3{0, 0, -1})
+		expect := mgl32.Vec3(test.target).Sub(test.eye).Normalize()
+		if !forward.ApproxFuncEqual(expect, near) {
+			t.Errorf("LookAt failed. Expected front %v, but got %v", expect, forward)
+		}
+	}
+
+	q := LookAt([3]float32{0, 0, 0}, [3]float32{1, 0, 0}, [3]float32{0, 1, 0})
+	if up := q.Rotate(mgl32.Vec3{0, 1, 0}); !up.ApproxFuncEqual(mgl32.Vec3{0, 1, 0}, near) {
+		t.Errorf("LookAt failed. Expected up %v, but got %v", mgl32.Vec3{0, 1, 0}, up)
+	}
+}
+
+// near compares floats using an absolute tolerance, as the expected values contain zeros
+func near(a, b float32) bool {
+	return math.Abs(float64(a-b)) < 1e-4
+}
@@ -0,0 +1,261 @@
+package rebound
+
+import (
+	"fmt"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+const (
+	pbrVShader = `
+	#version 410 core
+	layout (location = 0) in vec3 position;
+	layout (location = 1) in vec2 textureCoords;
+	layout (location = 3) in vec3 normal;
+	layout (location = 4) in vec4 tangent;
+
+	out vec3 FragPos;
+	out vec3 Normal;
+	out vec2 TexCoords;
+	out vec4 Tangent;
+
+	uniform mat4 model;
+	uniform mat4 normalMatrix;
+	uniform mat4 projection;
+	uniform mat4 view;
+
+	void main(void) {
+		FragPos = vec3(model * vec4(position, 1.0));
+		Normal = mat3(normalMatrix) * normal;
+		Tangent = vec4(mat3(model) * tangent.xyz, tangent.w);
+		TexCoords = textureCoords;
+
+		gl_Position = projection * view * vec4(FragPos, 1.0);
+	}` + "\x00"
+
+	pbrFShader = `
+	#version 410 core
+	out vec4 FragColor;
+
+	in vec3 FragPos;
+	in vec3 Normal;
+	in vec2 TexCoords;
+	in vec4 Tangent;
+
+	struct Material {
+		vec4 baseColor;
+		float metallic;
+		float roughness;
+		vec3 emissive;
+
+		sampler2D baseColorTexture;
+		sampler2D metallicRoughnessTexture;
+		sampler2D normalTexture;
+		sampler2D occlusionTexture;
+		sampler2D emissiveTexture;
+
+		bool hasBaseColorTexture;
+		bool hasMetallicRoughnessTexture;
+		bool hasNormalTexture;
+		bool hasOcclusionTexture;
+		bool hasEmissiveTexture;
+	};
+
+	struct DirLight {
+		vec3 direction;
+		vec3 colour;
+	};
+
+	struct PointLight {
+		vec3 position;
+		vec3 colour;
+
+		float constant;
+		float linear;
+		float quadratic;
+	};
+
+	uniform vec3 viewPos;
+	uniform vec3 ambient;
+	uniform Material material;
+	uniform DirLight light;
+	uniform int amountLights;
+
+	#define NR_POINT_LIGHTS 4
+	uniform PointLight pointLights[NR_POINT_LIGHTS];
+
+	const float PI = 3.14159265359;
+
+	vec3 BRDF(vec3 N, vec3 V, vec3 L, vec3 radiance, vec3 albedo, float metallic, float roughness, vec3 F0);
+
+	void main()
+	{
+		vec4 base = material.baseColor;
+		if (material.hasBaseColorTexture) {
+			vec4 texColour = texture(material.baseColorTexture, TexCoords);
+			base *= vec4(pow(texColour.rgb, vec3(2.2)), texColour.a);
+		}
+		if (base.a < 0.5) {
+			discard;
+		}
+
+		// the roughness is stored in the green channel and the metalness in the blue channel
+		float metallic = material.metallic;
+		float roughness = material.roughness;
+		if (material.hasMetallicRoughnessTexture) {
+			vec4 mr = texture(material.metallicRoughnessTexture, TexCoords);
+			roughness *= mr.g;
+			metallic *= mr.b;
+		}
+		metallic = clamp(metallic, 0.0, 1.0);
+		roughness = clamp(roughness, 0.04, 1.0);
+
+		vec3 N = normalize(Normal);
+		if (material.hasNormalTexture) {
+			vec3 T = normalize(Tangent.xyz - N * dot(N, Tangent.xyz));
+			vec3 B = cross(N, T) * Tangent.w;
+			N = normalize(mat3(T, B, N) * (texture(material.normalTexture, TexCoords).rgb * 2.0 - 1.0));
+		}
+		vec3 V = normalize(viewPos - FragPos);
+
+		vec3 albedo = base.rgb;
+		vec3 F0 = mix(vec3(0.04), albedo, metallic);
+
+		// Calculate directional light
+		vec3 colour = BRDF(N, V, normalize(-light.direction), light.colour, albedo, metallic, roughness, F0);
+
+		// Calculate point lights
+		int size = min(amountLights, NR_POINT_LIGHTS);
+		for (int i = 0; i < size; i++) {
+			vec3 L = pointLights[i].position - FragPos;
+			float distance = length(L);
+			float attenuation = 1.0 / (pointLights[i].constant + pointLights[i].linear * distance +
+					pointLights[i].quadratic * (distance * distance));
+			colour += BRDF(N, V, normalize(L), pointLights[i].colour * attenuation, albedo, metallic, roughness, F0);
+		}
+
+		// the ambient light approximates a white environment, which only the occlusion darkens
+		float occlusion = 1.0;
+		if (material.hasOcclusionTexture) {
+			occlusion = texture(material.occlusionTexture, TexCoords).r;
+		}
+		colour += ambient * (albedo * (1.0 - metallic) + F0) * occlusion;
+
+		vec3 emissive = material.emissive;
+		if (material.hasEmissiveTexture) {
+			emissive *= pow(texture(material.emissiveTexture, TexCoords).rgb, vec3(2.2));
+		}
+		colour += emissive;
+
+		// tone map and gamma correct the result
+		colour = colour / (colour + vec3(1.0));
+		FragColor = vec4(pow(colour, vec3(1.0 / 2.2)), 1.0);
+	}
+
+	// BRDF returns the light reflected towards the viewer using the Cook-Torrance model
+	vec3 BRDF(vec3 N, vec3 V, vec3 L, vec3 radiance, vec3 albedo, float metallic, float roughness, vec3 F0)
+	{
+		vec3 H = normalize(V + L);
+		float NdotL = max(dot(N, L), 0.0);
+		float NdotV = max(dot(N, V), 0.0001);
+		float NdotH = max(dot(N, H), 0.0);
+
+		// GGX normal distribution
+		float a2 = pow(roughness, 4.0);
+		float d = NdotH * NdotH * (a2 - 1.0) + 1.0;
+		float D = a2 / (PI * d * d);
+
+		// Smith geometry using Schlick-GGX
+		float k = (roughness + 1.0) * (roughness + 1.0) / 8.0;
+		float G = NdotV / (NdotV * (1.0 - k) + k) * NdotL / (NdotL * (1.0 - k) + k);
+
+		// Fresnel-Schlick
+		vec3 F = F0 + (1.0 - F0) * pow(clamp(1.0 - max(dot(H, V), 0.0), 0.0, 1.0), 5.0);
+
+		vec3 specular = D * G * F / (4.0 * NdotV * NdotL + 0.0001);
+		vec3 kD = (vec3(1.0) - F) * (1.0 - metallic);
+		return (kD * albedo / PI + specular) * radiance * NdotL;
+	}
+	` + "\x00"
+)
+
+// PBRShader renders materials physically based, using the metallic-roughness model of glTF.
+// Every texture of the [Material] is used, and the normal texture only for meshes with tangents.
+// The lights use their Colour as intensity, Ambient lights the scene evenly
+type PBRShader struct {
+	id          uint32
+	SceneLight  *Light
+	PointLights []PointLight
+	Ambient     [3]float32
+}
+
+// NewPBRShader creates a physically based shader, which can replace the [BasicShader] of a [RenderSystem]
+func NewPBRShader() (*PBRShader, error) {
+	id, err := NewShader(pbrVShader, pbrFShader)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PBRShader{
+		id: id,
+		SceneLight: &Light{
+			Direction: [3]float32{-0.2, -1.0, -0.3},
+			Colour:    [3]float32{3, 3, 3},
+		},
+		Ambient: [3]float32{0.3, 0.3, 0.3},
+	}, nil
+}
+
+// ID returns the shader id
+func (ps *PBRShader) ID() uint32 {
+	return ps.id
+}
+
+// Setup loads the lights, camera and texture units into the shader
+func (ps *PBRShader) Setup(c Camera) {
+	if ps.SceneLight != nil {
+		LoadVec3(ps, "light.direction", ps.SceneLight.Direction)
+		LoadVec3(ps, "light.colour", ps.SceneLight.Colour)
+	} else {
+		LoadVec3(ps, "light.colour", [3]float32{})
+	}
+	LoadVec3(ps, "ambient", ps.Ambient)
+
+	LoadInt(ps, "amountLights", len(ps.PointLights))
+	for index, l := range ps.PointLights {
+		prefix := fmt.Sprintf("pointLights[%v].", index)
+		LoadVec3(ps, prefix+"position", l.Position)
+		LoadVec3(ps, prefix+"colour", l.Colour)
+		LoadFloat(ps, prefix+"constant", l.Constant)
+		LoadFloat(ps, prefix+"linear", l.Linear)
+		LoadFloat(ps, prefix+"quadratic", l.Quadratic)
+	}
+
+	LoadInt(ps, "material.baseColorTexture", BaseColorUnit)
+	LoadInt(ps, "material.metallicRoughnessTexture", MetallicRoughnessUnit)
+	LoadInt(ps, "material.normalTexture", NormalUnit)
+	LoadInt(ps, "material.occlusionTexture", OcclusionUnit)
+	LoadInt(ps, "material.emissiveTexture", EmissiveUnit)
+
+	LoadVec3(ps, "viewPos", c.Position)
+	LoadMat(ps, "projection", c.Projection)
+	LoadMat(ps, "view", NewViewMatrix(c))
+}
+
+// Render loads the material of the RenderComponent and the model matrix into the shader
+func (ps *PBRShader) Render(rc RenderComponent, model [16]float32) {
+	m := rc.material()
+	LoadVec4(ps, "material.baseColor", m.BaseColor)
+	LoadFloat(ps, "material.metallic", m.MetallicFactor)
+	LoadFloat(ps, "material.roughness", m.RoughnessFactor)
+	LoadVec3(ps, "material.emissive", m.EmissiveFactor)
+
+	LoadBool(ps, "material.hasBaseColorTexture", m.BaseColorTexture != nil)
+	LoadBool(ps, "material.hasMetallicRoughnessTexture", m.MetallicRoughnessTexture != nil)
+	LoadBool(ps, "material.hasNormalTexture", m.NormalTexture != nil && rc.Mesh.hasAttribute(TANGENTS))
+	LoadBool(ps, "material.hasOcclusionTexture", m.OcclusionTexture != nil)
+	LoadBool(ps, "material.hasEmissiveTexture", m.EmmisiveTexture != nil)
+
+	LoadMat(ps, "model", model)
+	LoadMat(ps, "normalMatrix", mgl32.Mat4(model).Inv().Transpose())
+}
@@ -0,0 +1,232 @@
+// Package profile records how long each part of a frame takes, such as the update of every system.
+// Timings are kept as rolling statistics and can be captured and exported as a Chrome trace,
+// which can be opened in chrome://tracing or Perfetto
+package profile
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// FrameStat is the name of the [Stat] that holds the time between 2 calls of [Profiler.EndFrame]
+const FrameStat = "Frame"
+
+// Profiler records the time spent in named spans every frame.
+// A disabled Profiler records nothing, so its spans can be left in place at almost no cost
+type Profiler struct {
+	enabled atomic.Bool
+	m       sync.Mutex
+	window  int
+	origin  time.Time
+	frame   time.Time
+	current map[string]time.Duration
+	samples map[string]*samples
+	lanes   []bool
+	capture *Capture
+}
+
+var (
+	defaultProfiler     *Profiler
+	defaultProfilerOnce sync.Once
+)
+
+// New creates a disabled Profiler that keeps the statistics of the last window frames
+func New(window int) *Profiler {
+	if window < 1 {
+		window = 1
+	}
+	return &Profiler{
+		window:  window,
+		origin:  time.Now(),
+		current: make(map[string]time.Duration),
+		samples: make(map[string]*samples),
+	}
+}
+
+// Default returns the Profiler used to instrument the world, its systems and the main thread.
+// It keeps the statistics of the last 120 frames and is disabled until [Profiler.Enable] is called
+func Default() *Profiler {
+	defaultProfilerOnce.Do(func() {
+		defaultProfiler = New(120)
+	})
+	return defaultProfiler
+}
+
+// Enable starts recording spans
+func (p *Profiler) Enable() {
+	p.m.Lock()
+	p.frame = time.Now()
+	p.m.Unlock()
+	p.enabled.Store(true)
+}
+
+// Disable stops recording spans, the statistics recorded so far are kept
+func (p *Profiler) Disable() {
+	p.enabled.Store(false)
+}
+
+// Enabled returns true if the Profiler records spans
+func (p *Profiler) Enabled() bool {
+	return p.enabled.Load()
+}
+
+// Span measures the time between its start and a call to [Span.End]
+type Span struct {
+	p        *Profiler
+	name     string
+	category string
+	lane     int
+	start    time.Time
+}
+
+// Begin starts a span with the given name, i.e. the name of a system.
+// The category groups spans within a [Capture], such as "system".
+// Spans can be started from any goroutine and may overlap
+func (p *Profiler) Begin(name, category string) Span {
+	if !p.enabled.Load() {
+		return Span{}
+	}
+
+	p.m.Lock()
+	lane := 0
+	for lane < len(p.lanes) && p.lanes[lane] {
+		lane++
+	}
+	if lane == len(p.lanes) {
+		p.lanes = append(p.lanes, true)
+	}
+	p.lanes[lane] = true
+	p.m.Unlock()
+
+	return Span{p: p, name: name, category: category, lane: lane, start: time.Now()}
+}
+
+// End stops the span and adds its duration to the current frame
+func (s Span) End() {
+	if s.p == nil {
+		return
+	}
+	d := time.Since(s.start)
+
+	p := s.p
+	p.m.Lock()
+	p.lanes[s.lane] = false
+	p.current[s.name] += d
+	if p.capture != nil {
+		p.capture.events = append(p.capture.events, event{
+			name:     s.name,
+			category: s.category,
+			lane:     s.lane,
+			start:    s.start.Sub(p.origin),
+			duration: d,
+		})
+	}
+	p.m.Unlock()
+}
+
+// EndFrame ends the current frame, adding the time spent in every span during the frame to the statistics.
+// It is called by the game loop once every frame
+func (p *Profiler) EndFrame() {
+	if !p.enabled.Load() {
+		return
+	}
+
+	now := time.Now()
+	p.m.Lock()
+	p.record(FrameStat, now.Sub(p.frame))
+	for name, d := range p.current {
+		p.record(name, d)
+		delete(p.current, name)
+	}
+	p.frame = now
+	p.m.Unlock()
+}
+
+// record adds the sample of a frame to the statistics of the name, the caller must hold the lock of the Profiler
+func (p *Profiler) record(name string, d time.Duration) {
+	s, exists := p.samples[name]
+	if !exists {
+		s = &samples{values: make([]time.Duration, 0, p.window)}
+		p.samples[name] = s
+	}
+	s.add(d)
+}
+
+// Stat holds the timings of a span over the frames in which it was recorded, within the window of the [Profiler]
+type Stat struct {
+	Name    string
+	Frames  int
+	Last    time.Duration
+	Average time.Duration
+	Min     time.Duration
+	Max     time.Duration
+}
+
+// Stats returns the statistics of every span, with the slowest span on average first
+func (p *Profiler) Stats() []Stat {
+	p.m.Lock()
+	stats := make([]Stat, 0, len(p.samples))
+	for name, s := range p.samples {
+		stats = append(stats, s.stat(name))
+	}
+	p.m.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Average != stats[j].Average {
+			return stats[i].Average > stats[j].Average
+		}
+		return stats[i].Name < stats[j].Name
+	})
+	return stats
+}
+
+// Stat returns the statistics of the span with the given name, false when it was never recorded
+func (p *Profiler) Stat(name string) (Stat, bool) {
+	p.m.Lock()
+	defer p.m.Unlock()
+	s, exists := p.samples[name]
+	if !exists {
+		return Stat{}, false
+	}
+	return s.stat(name), true
+}
+
+// Reset removes every statistic recorded so far
+func (p *Profiler) Reset() {
+	p.m.Lock()
+	p.current = make(map[string]time.Duration)
+	p.samples = make(map[string]*samples)
+	p.frame = time.Now()
+	p.m.Unlock()
+}
+
+// samples is a ring buffer holding the duration of a span in the most recent frames
+type samples struct {
+	values []time.Duration
+	next   int
+	last   time.Duration
+}
+
+func (s *samples) add(d time.Duration) {
+	s.last = d
+	if len(s.values) < cap(s.values) {
+		s.values = append(s.values, d)
+		return
+	}
+	s.values[s.next] = d
+	s.next = (s.next + 1) % len(s.values)
+}
+
+func (s *samples) stat(name string) Stat {
+	stat := Stat{Name: name, Frames: len(s.values), Last: s.last, Min: s.values[0], Max: s.values[0]}
+	var total time.Duration
+	for _, v := range s.values {
+		total += v
+		stat.Min = min(stat.Min, v)
+		stat.Max = max(stat.Max, v)
+	}
+	stat.Average = total / time.Duration(len(s.values))
+	return stat
+}
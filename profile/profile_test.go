@@ -0,0 +1,81 @@
+package profile
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestDisabled(t *testing.T) {
+	p := New(10)
+	p.Begin("System", "system").End()
+	p.EndFrame()
+
+	if stats := p.Stats(); len(stats) != 0 {
+		t.Errorf("Begin failed. Expected no statistics while disabled, but got %v", stats)
+	}
+}
+
+func TestStats(t *testing.T) {
+	p := New(2)
+	p.Enable()
+
+	for _, d := range []time.Duration{time.Millisecond, 2 * time.Millisecond, 3 * time.Millisecond} {
+		s := p.Begin("System", "system")
+		time.Sleep(d)
+		s.End()
+		p.EndFrame()
+	}
+
+	stat, ok := p.Stat("System")
+	if !ok {
+		t.Fatalf("Stat failed. Expected statistics of System")
+	}
+	if stat.Frames != 2 {
+		t.Errorf("Stat failed. Expected a window of %d frames, but got %d", 2, stat.Frames)
+	}
+	if stat.Min < 2*time.Millisecond || stat.Last < 3*time.Millisecond || stat.Max != stat.Last {
+		t.Errorf("Stat failed. Expected the first frame to leave the window, but got %+v", stat)
+	}
+	if _, ok := p.Stat(FrameStat); !ok {
+		t.Errorf("Stat failed. Expected statistics of %s", FrameStat)
+	}
+}
+
+func TestWriteTrace(t *testing.T) {
+	p := New(10)
+	p.Enable()
+	p.StartCapture()
+
+	outer := p.Begin("World.Update", "world")
+	inner := p.Begin("System", "system")
+	inner.End()
+	outer.End()
+	p.Begin("Render", "render").End()
+	c := p.StopCapture()
+	p.Begin("Ignored", "system").End()
+
+	if c.Len() != 3 {
+		t.Fatalf("StopCapture failed. Expected %d spans, but got %d", 3, c.Len())
+	}
+
+	var buf bytes.Buffer
+	if err := c.WriteTrace(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var trace struct {
+		TraceEvents []traceEvent `json:"traceEvents"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &trace); err != nil {
+		t.Fatal(err)
+	}
+	if len(trace.TraceEvents) != 3 {
+		t.Fatalf("WriteTrace failed. Expected %d events, but got %d", 3, len(trace.TraceEvents))
+	}
+	system := trace.TraceEvents[0]
+	if system.Name != "System" || system.Phase != "X" || system.Thread != 1 {
+		t.Errorf("WriteTrace failed. Expected the nested span on thread 1, but got %+v", system)
+	}
+}
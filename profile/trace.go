@@ -0,0 +1,75 @@
+package profile
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// Capture holds every span recorded between [Profiler.StartCapture] and [Profiler.StopCapture]
+type Capture struct {
+	events []event
+}
+
+// event is a single span within a Capture
+type event struct {
+	name     string
+	category string
+	lane     int
+	start    time.Duration
+	duration time.Duration
+}
+
+// traceEvent is an event in the Chrome trace event format, where times are in microseconds
+type traceEvent struct {
+	Name     string  `json:"name"`
+	Category string  `json:"cat,omitempty"`
+	Phase    string  `json:"ph"`
+	Time     float64 `json:"ts"`
+	Duration float64 `json:"dur"`
+	Process  int     `json:"pid"`
+	Thread   int     `json:"tid"`
+}
+
+// StartCapture starts recording every span, replacing the capture that is in progress
+func (p *Profiler) StartCapture() {
+	p.m.Lock()
+	p.capture = &Capture{}
+	p.m.Unlock()
+}
+
+// StopCapture stops recording spans and returns the capture, or nil when no capture was started
+func (p *Profiler) StopCapture() *Capture {
+	p.m.Lock()
+	c := p.capture
+	p.capture = nil
+	p.m.Unlock()
+	return c
+}
+
+// Len returns the number of spans in the Capture
+func (c *Capture) Len() int {
+	return len(c.events)
+}
+
+// WriteTrace writes the Capture as Chrome trace event JSON.
+// Spans that overlap in time, such as systems that run in parallel, are placed on separate threads of the trace
+func (c *Capture) WriteTrace(w io.Writer) error {
+	events := make([]traceEvent, len(c.events))
+	for i, e := range c.events {
+		events[i] = traceEvent{
+			Name:     e.name,
+			Category: e.category,
+			Phase:    "X",
+			Time:     float64(e.start.Nanoseconds()) / 1e3,
+			Duration: float64(e.duration.Nanoseconds()) / 1e3,
+			Process:  1,
+			Thread:   e.lane,
+		}
+	}
+
+	return json.NewEncoder(w).Encode(struct {
+		TraceEvents     []traceEvent `json:"traceEvents"`
+		DisplayTimeUnit string       `json:"displayTimeUnit"`
+	}{events, "ms"})
+}
@@ -7,13 +7,28 @@ import (
 	"github.com/luukdegram/rebound/ecs"
 	"github.com/luukdegram/rebound/internal/display"
 	"github.com/luukdegram/rebound/internal/thread"
+	"github.com/luukdegram/rebound/profile"
 )
 
 //RunOptions allows you to set the initial width, height and title of the application
+//World sets the [ecs.World] that is updated every frame, the default world is used when nil.
+//The events of the window, such as [KeyEvent], are queued on the event bus of the World.
+//FixedRate sets the number of fixed updates per second, see [ecs.World.FixedUpdate], the world is updated once per frame when 0.
+//MaxCatchUp limits the number of fixed updates per frame, 5 when not set.
+//Clock controls the time scale, the time passes at normal speed when nil.
+//Profile enables the default [profile.Profiler], which records the time spent in every system each frame.
+//Capture starts capturing the window from the first frame, see [StartCapture].
+//The systems receive the passed time in seconds, which is a breaking change as they used to receive milliseconds
 type RunOptions struct {
-	Width  int
-	Height int
-	Title  string
+	Width      int
+	Height     int
+	Title      string
+	World      *ecs.World
+	FixedRate  float64
+	MaxCatchUp int
+	Clock      *Clock
+	Profile    bool
+	Capture    *CaptureOptions
 }
 
 // Run starts a new Rebound Application. It will initialize all base systems needed to run the engine.
@@ -21,6 +36,8 @@ type RunOptions struct {
 func Run(options RunOptions, setup func()) error {
 	ctx, cancel := context.WithCancel(context.Background())
 
+	// the mainthread runs before the game starts, so every call to it is queued, such as initializing the window
+	thread.Start()
 	ch := make(chan error, 1)
 	go func() {
 		defer cancel()
@@ -43,19 +60,53 @@ func run(options RunOptions, setup func()) error {
 		return err
 	}
 
+	world := options.World
+	if world == nil {
+		world = ecs.DefaultWorld()
+	}
+	window.SetEventHandler(windowEvents{world.Events()})
+
 	setup()
 
+	clock := options.Clock
+	if clock == nil {
+		clock = NewClock()
+	}
+	l := newLoop(world, options.FixedRate, options.MaxCatchUp)
+	if options.Profile {
+		profile.Default().Enable()
+	}
+	if options.Capture != nil {
+		if err := StartCapture(*options.Capture); err != nil {
+			return err
+		}
+	}
+
+	err = world.Sort()
 	st := time.Now()
-	for !window.ShouldClose() {
-		delta := time.Now().Sub(st).Seconds() * 1000
-		ecs.GetManager().Update(delta)
+	for err == nil && !window.ShouldClose() {
+		now := time.Now()
+		delta := now.Sub(st).Seconds()
+		st = now
+		// a capture runs at its own frame rate, regardless of the time a frame takes
+		if c := activeCapture(); c != nil {
+			delta = c.Step()
+		}
+		err = l.advance(delta * clock.TimeScale())
+		if err == nil {
+			err = captureFrame()
+		}
 		window.Update()
-		st = time.Now()
+		profile.Default().EndFrame()
 	}
 
+	if cerr := StopCapture(); err == nil {
+		err = cerr
+	}
+	world.Shutdown()
 	CleanUpShaders()
 	CleanUp()
 	window.Close()
 
-	return nil
+	return err
 }
@@ -1,9 +1,11 @@
 package rebound
 
 import (
-	"github.com/go-gl/gl/v4.1-core/gl"
+	"sync"
+
 	"github.com/luukdegram/rebound/ecs"
 	"github.com/luukdegram/rebound/internal/thread"
+	"github.com/luukdegram/rebound/profile"
 )
 
 const (
@@ -31,13 +33,25 @@ const (
 )
 
 //RenderSystem handles the rendering of all entities
+//It does not declare its component access, which makes sure it never runs in parallel with other systems as it requires the main thread
+//When ReleaseMeshes is true, the GPU data of a mesh is removed by the next Update once nothing references it anymore, see [RetainMesh].
+//Releasing the mesh at the next Update allows entities to be replaced, i.e. by [ecs.World.Restore], without reloading their meshes
+//When Target is set, the system renders into the [RenderTarget] instead of the window or the Target of its Camera.
+//Views are additional cameras that render the same entities into their own Target before the Camera renders, i.e. for a minimap
 type RenderSystem struct {
 	ecs.BaseSystem
-	drawPolygon bool
-	Camera      *Camera
-	Shader      Shader
-	BaseColour  Colour
-	Skybox      *Skybox
+	drawPolygon   bool
+	Camera        *Camera
+	Target        *RenderTarget
+	Views         []*Camera
+	Shader        Shader
+	BaseColour    Colour
+	Skybox        *Skybox
+	ReleaseMeshes bool
+	refMutex      *sync.Mutex
+	meshes        map[ecs.EntityID]*Mesh
+	released      map[*Mesh]struct{}
+	alpha         float64
 }
 
 //Attribute is vbo that stores data such as texture coordinates
@@ -51,11 +65,14 @@ type Attribute struct {
 type AttributeType int
 
 // RenderComponent holds the data to render an entity
+// Position, Rotation and Scale are only used when the entity has no [Transform]
+// MaterialOverride replaces the material of the mesh for this entity only, i.e. for a single instance of an [ecs.Prefab]
 type RenderComponent struct {
 	*Mesh
-	Rotation [3]float32
-	Position [3]float32
-	Scale    [3]float32
+	Rotation         [3]float32
+	Position         [3]float32
+	Scale            [3]float32
+	MaterialOverride *Material
 }
 
 //NewRenderSystem returns a new RendererSystem with default settings
@@ -64,6 +81,10 @@ func NewRenderSystem() (*RenderSystem, error) {
 		BaseSystem:  ecs.NewBaseSystem(),
 		drawPolygon: false,
 		BaseColour:  Colour{0.1, 0.1, 0.1, 1},
+		alpha:       1,
+		refMutex:    &sync.Mutex{},
+		meshes:      make(map[ecs.EntityID]*Mesh),
+		released:    make(map[*Mesh]struct{}),
 	}
 
 	shader, err := NewBasicShader()
@@ -76,29 +97,61 @@ func NewRenderSystem() (*RenderSystem, error) {
 }
 
 //Update draws all entities within a RendererSystem
+//The time spent drawing on the main thread is recorded by the default [profile.Profiler] as "RenderSystem.Draw"
 func (rs *RenderSystem) Update(dt float64) {
+	rs.unloadReleased()
+
 	thread.Call(func() {
-		rs.prepare()
-		startShader(rs.Shader)
-		rs.Shader.Setup(*rs.Camera)
-		for _, e := range rs.BaseSystem.Entities() {
-			rc := e.Component(RenderComponentName).(*RenderComponent)
-			rs.Shader.Render(*rc)
-			render(*rc)
+		span := profile.Default().Begin("RenderSystem.Draw", "render")
+		defer span.End()
+
+		for _, view := range rs.Views {
+			if view.Target != nil {
+				rs.draw(view, view.Target)
+			}
 		}
-		stopShader()
 
-		//as last, render our skybox
-		rs.renderSkybox()
+		rs.draw(rs.Camera, rs.target())
+		CurrentBackend().BindRenderTarget(0)
 	})
 }
 
+// target returns the render target of the system or its camera, nil when rendering into the window
+func (rs *RenderSystem) target() *RenderTarget {
+	if rs.Target != nil {
+		return rs.Target
+	}
+	return rs.Camera.Target
+}
+
+// draw renders all entities as seen by the camera into the target, or the window when target is nil.
+// Entities that show the texture of the target are skipped, as a texture cannot be sampled while rendering into it
+func (rs *RenderSystem) draw(camera *Camera, target *RenderTarget) {
+	CurrentBackend().BindRenderTarget(target.id())
+	rs.prepare()
+	startShader(rs.Shader)
+	rs.Shader.Setup(*camera)
+	for _, e := range rs.BaseSystem.Entities() {
+		rc, _ := ecs.Get[*RenderComponent](e)
+		if target != nil && rc.material().shows(target.Texture) {
+			continue
+		}
+		rs.Shader.Render(*rc, modelMatrix(e, rc, rs.alpha))
+		rs.render(*rc)
+	}
+	stopShader()
+
+	//as last, render our skybox
+	rs.renderSkybox(camera)
+}
+
 // AddEntities adds entities to the Render System.
 // TODO: This differs from the base addEntities function as this setups the entities to be batch rendered
 func (rs *RenderSystem) AddEntities(entities ...*ecs.Entity) {
 	for _, e := range entities {
-		if e.HasComponent(RenderComponentName) {
+		if _, exists := rs.BaseSystem.Entities()[e.ID()]; !exists && e.HasComponent(RenderComponentName) {
 			rs.BaseSystem.AddEntities(e)
+			rs.reference(e)
 		}
 
 		if len(e.Children()) > 0 {
@@ -107,6 +160,76 @@ func (rs *RenderSystem) AddEntities(entities ...*ecs.Entity) {
 	}
 }
 
+// RemoveEntity removes the entity from the Render System.
+// If ReleaseMeshes is set, the mesh of the entity is removed from the GPU by the next Update when nothing else references it
+func (rs *RenderSystem) RemoveEntity(e *ecs.Entity) {
+	if _, exists := rs.BaseSystem.Entities()[e.ID()]; !exists {
+		return
+	}
+	rs.BaseSystem.RemoveEntity(e)
+	rs.release(e)
+}
+
+// reference retains the mesh the entity uses, so it can be released when the entity is removed
+func (rs *RenderSystem) reference(e *ecs.Entity) {
+	rc, ok := ecs.Get[*RenderComponent](e)
+	if !ok || rc.Mesh == nil {
+		return
+	}
+
+	rs.refMutex.Lock()
+	rs.meshes[e.ID()] = rc.Mesh
+	rs.refMutex.Unlock()
+	RetainMesh(rc.Mesh)
+}
+
+// release releases the mesh the entity used and marks the mesh as released when it's no longer referenced
+func (rs *RenderSystem) release(e *ecs.Entity) {
+	rs.refMutex.Lock()
+	defer rs.refMutex.Unlock()
+
+	mesh, exists := rs.meshes[e.ID()]
+	if !exists {
+		return
+	}
+	delete(rs.meshes, e.ID())
+	if ReleaseMesh(mesh) && rs.ReleaseMeshes {
+		rs.released[mesh] = struct{}{}
+	}
+}
+
+// unloadReleased unloads the released meshes that were not retained again since they were released
+func (rs *RenderSystem) unloadReleased() {
+	rs.refMutex.Lock()
+	var unused []*Mesh
+	for mesh := range rs.released {
+		if !meshRetained(mesh) {
+			unused = append(unused, mesh)
+		}
+	}
+	clear(rs.released)
+	rs.refMutex.Unlock()
+
+	for _, mesh := range unused {
+		UnloadMesh(mesh)
+	}
+}
+
+// Query returns the components an entity requires to be rendered, which allows the [ecs.Manager] to add entities automatically
+func (rs *RenderSystem) Query() ecs.Query {
+	return ecs.Query{RenderComponentName}
+}
+
+// Interpolate sets the fraction of the fixed time step used to interpolate the transforms of the entities
+func (rs *RenderSystem) Interpolate(alpha float64) {
+	rs.alpha = alpha
+}
+
+// Stage returns the stage of the rendering system, which runs after all other systems
+func (rs *RenderSystem) Stage() ecs.Stage {
+	return ecs.StageRender
+}
+
 // Name returns the name of the rendering system
 func (rs *RenderSystem) Name() string {
 	return "RenderSystem"
@@ -117,6 +240,14 @@ func (rc *RenderComponent) Name() string {
 	return RenderComponentName
 }
 
+// material returns the material the entity is rendered with
+func (rc *RenderComponent) material() *Material {
+	if rc.MaterialOverride != nil {
+		return rc.MaterialOverride
+	}
+	return rc.Material
+}
+
 //NewCamera creates a new camera and attaches it to the renderer
 func (rs *RenderSystem) NewCamera(width int, height int) {
 	var camera *Camera
@@ -140,16 +271,27 @@ func (rs *RenderSystem) NewCamera(width int, height int) {
 
 //Prepare cleans the screen for the next draw
 func (rs *RenderSystem) prepare() {
-	gl.Enable(gl.CULL_FACE)
-	gl.CullFace(gl.BACK)
-	gl.Enable(gl.DEPTH_TEST)
-	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
-	gl.ClearColor(rs.BaseColour.R, rs.BaseColour.G, rs.BaseColour.B, rs.BaseColour.A)
-	if rs.drawPolygon {
-		gl.PolygonMode(gl.FRONT_AND_BACK, gl.LINE)
-	} else {
-		gl.PolygonMode(gl.FRONT_AND_BACK, gl.FILL)
+	CurrentBackend().SetState(rs.state(false))
+	CurrentBackend().Clear(rs.BaseColour)
+}
+
+// state returns the render state used to draw meshes, transparent meshes are drawn without culling
+func (rs *RenderSystem) state(transparent bool) RenderState {
+	return RenderState{
+		CullFace:  !transparent,
+		DepthTest: true,
+		DepthFunc: DepthLess,
+		Wireframe: rs.drawPolygon,
+	}
+}
+
+// modelMatrix returns the world matrix of the Transform of the entity, interpolated using alpha,
+// or the transformation matrix of the RenderComponent when the entity has no Transform
+func modelMatrix(e *ecs.Entity, rc *RenderComponent, alpha float64) [16]float32 {
+	if t, ok := ecs.Get[*Transform](e); ok {
+		return t.Interpolated(alpha)
 	}
+	return NewTransformationMatrix(rc.Position, rc.Rotation, rc.Scale)
 }
 
 //TogglePolygons enables/disables drawing the polygons
@@ -157,51 +299,38 @@ func (rs *RenderSystem) TogglePolygons() {
 	rs.drawPolygon = !rs.drawPolygon
 }
 
-func render(rc RenderComponent) {
-	// Bind the VAO
-	gl.BindVertexArray(rc.ID)
-
-	// Enable all the vertex attributes (position, texcoords, colors, normals, etc)
-	for _, a := range rc.Attributes {
-		gl.EnableVertexAttribArray(uint32(a.Type))
-	}
+func (rs *RenderSystem) render(rc RenderComponent) {
+	b := CurrentBackend()
+	material := rc.material()
 
 	// If transparent, disable culling
-	if rc.Material.Transparent {
-		gl.Disable(gl.CULL_FACE)
-	}
+	b.SetState(rs.state(material.Transparent))
 
-	// if texture exists, bind texture
-	if rc.Material.BaseColorTexture != nil {
-		gl.BindTexture(gl.TEXTURE_2D, *rc.Material.BaseColorTexture)
+	// bind every texture the material has to its own unit
+	for unit, texture := range material.textures() {
+		if texture != nil {
+			b.BindTexture(unit, *texture)
+		}
 	}
 
 	// Finally, draw the model
-	gl.DrawElements(gl.TRIANGLES, int32(rc.VertexCount()), gl.UNSIGNED_INT, gl.Ptr(nil))
-
-	// Cleanup, disable attributes and unbind vao
-	for _, a := range rc.Attributes {
-		gl.DisableVertexAttribArray(uint32(a.Type))
-	}
-	gl.BindVertexArray(0)
+	b.Draw(rc.ID, rc.VertexCount())
 }
 
-// renderSkybox renders a Skybox into the scene
-func (rs *RenderSystem) renderSkybox() {
+// renderSkybox renders a Skybox into the scene as seen by the camera
+func (rs *RenderSystem) renderSkybox(camera *Camera) {
 	if rs.Skybox == nil {
 		return
 	}
 	sb := rs.Skybox
-	gl.DepthFunc(gl.LEQUAL)
+	b := CurrentBackend()
+	state := rs.state(false)
+	state.DepthFunc = DepthLessEqual
+	b.SetState(state)
 	startShader(sb.shader)
-	sb.shader.Setup(*rs.Camera)
-	gl.BindVertexArray(sb.mesh.ID)
-	gl.EnableVertexAttribArray(0)
-	gl.ActiveTexture(gl.TEXTURE0)
-	gl.BindTexture(gl.TEXTURE_CUBE_MAP, sb.texture)
-	gl.DrawArrays(gl.TRIANGLES, 0, 36)
-	gl.DisableVertexAttribArray(0)
-	gl.BindVertexArray(0)
-	gl.DepthFunc(gl.LESS)
+	sb.shader.Setup(*camera)
+	b.BindTexture(0, sb.texture)
+	b.Draw(sb.mesh.ID, 36)
+	b.SetState(rs.state(false))
 	stopShader()
 }
@@ -0,0 +1,98 @@
+package rebound
+
+import (
+	"github.com/luukdegram/rebound/internal/thread"
+)
+
+// RenderTarget is an offscreen framebuffer with a colour texture and, unless Depth is [DepthNone], a depth buffer.
+// A [RenderSystem] or a [Camera] renders into it when set as their Target, after which Texture holds the result.
+// Texture can be used as the texture of a [Material], i.e. to build security camera screens, minimaps or portals.
+// Like a framebuffer in OpenGL, the first row of Texture is the bottom of the rendered image
+type RenderTarget struct {
+	ID      uint32
+	Texture uint32
+	Width   int
+	Height  int
+	Format  TextureFormat
+	Depth   DepthFormat
+}
+
+// NewRenderTarget creates a render target of the given size within the current [Backend]
+func NewRenderTarget(width, height int, format TextureFormat, depth DepthFormat) (*RenderTarget, error) {
+	t := &RenderTarget{Width: width, Height: height, Format: format, Depth: depth}
+	err := thread.CallErr(func() error {
+		var err error
+		t.ID, t.Texture, err = CurrentBackend().CreateRenderTarget(width, height, format, depth)
+		if err == nil {
+			renderTargets[t.ID] = struct{}{}
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Delete removes the render target and its texture from the current [Backend]
+func (t *RenderTarget) Delete() {
+	thread.Call(func() {
+		CurrentBackend().DeleteRenderTarget(t.ID)
+		delete(renderTargets, t.ID)
+	})
+
+	t.ID = 0
+	t.Texture = 0
+}
+
+// Aspect returns the aspect ratio of the render target
+func (t *RenderTarget) Aspect() float32 {
+	if t.Height == 0 {
+		return 1
+	}
+	return float32(t.Width) / float32(t.Height)
+}
+
+// NewCamera returns a camera with the same defaults as [RenderSystem.NewCamera] that renders into the render target
+func (t *RenderTarget) NewCamera() *Camera {
+	c := &Camera{
+		FOV:       105,
+		NearPlane: 0.1,
+		FarPlane:  100,
+		Target:    t,
+	}
+	c.Projection = NewProjectionMatrix(c.FOV, t.Aspect(), c.NearPlane, c.FarPlane)
+	return c
+}
+
+// Material returns a material that shows the texture of the render target
+func (t *RenderTarget) Material() *Material {
+	texture := t.Texture
+	return &Material{
+		PBRMetallicRoughness: PBRMetallicRoughness{
+			BaseColor:        [4]float32{1, 1, 1, 1},
+			BaseColorTexture: &texture,
+		},
+	}
+}
+
+// id returns the id of the render target, where a nil target is the window
+func (t *RenderTarget) id() uint32 {
+	if t == nil {
+		return 0
+	}
+	return t.ID
+}
+
+// shows returns whether the material uses the texture
+func (m *Material) shows(texture uint32) bool {
+	if m == nil {
+		return false
+	}
+	for _, t := range m.textures() {
+		if t != nil && *t == texture {
+			return true
+		}
+	}
+	return false
+}
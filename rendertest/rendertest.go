@@ -0,0 +1,203 @@
+// Package rendertest renders scenes without a window using the [rebound.SoftwareBackend]
+// and compares the result with golden images, to catch changes that alter the rendered pixels.
+//
+// Run the tests with the -update flag to write the current renders as new golden images:
+//
+//	go test ./... -update
+package rendertest
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/luukdegram/rebound"
+	"github.com/luukdegram/rebound/ecs"
+)
+
+var update = flag.Bool("update", false, "write the rendered images as new golden images")
+
+// Options changes how an image is compared with its golden image
+type Options struct {
+	// Dir is the directory holding the golden images, testdata/golden when empty
+	Dir string
+	// Threshold is the perceptual difference, from 0 to 1, at which 2 pixels are considered different, 0.1 when 0
+	Threshold float64
+	// MaxDiff is the fraction of the pixels that may differ before the comparison fails
+	MaxDiff float64
+}
+
+// Render renders a single frame of a scene with the given size using a new [rebound.SoftwareBackend].
+// The setup adds the entities and sets up the camera, the RenderSystem is part of the world, together with a [rebound.TransformSystem].
+// The backend is set for the duration of the test and all loaded data is cleaned up afterwards,
+// which means tests that render must not run in parallel
+func Render(t testing.TB, width, height int, setup func(w *ecs.World, rs *rebound.RenderSystem)) *image.RGBA {
+	t.Helper()
+	b := rebound.NewSoftwareBackend(width, height)
+	rebound.SetBackend(b)
+	t.Cleanup(func() {
+		rebound.CleanUp()
+		rebound.CleanUpShaders()
+		rebound.SetBackend(nil)
+	})
+
+	rs, err := rebound.NewRenderSystem()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rs.NewCamera(width, height)
+
+	w := ecs.NewWorld()
+	if err := w.AddSystems(rebound.NewTransformSystem(), rs); err != nil {
+		t.Fatal(err)
+	}
+	setup(w, rs)
+
+	if err := w.Update(0); err != nil {
+		t.Fatal(err)
+	}
+	return b.Image()
+}
+
+// Golden compares the image with the golden image of the given name.
+// On a mismatch the test fails, and the rendered image and an image highlighting the differences are written
+// next to the golden image as name.actual.png and name.diff.png.
+// With the -update flag the image is written as the new golden image instead
+func Golden(t testing.TB, name string, img image.Image, opts Options) {
+	t.Helper()
+	if opts.Dir == "" {
+		opts.Dir = filepath.Join("testdata", "golden")
+	}
+	if opts.Threshold == 0 {
+		opts.Threshold = 0.1
+	}
+
+	path := filepath.Join(opts.Dir, name+".png")
+	actualPath := filepath.Join(opts.Dir, name+".actual.png")
+	diffPath := filepath.Join(opts.Dir, name+".diff.png")
+
+	if *update {
+		if err := writePNG(path, img); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("updated golden image %s", path)
+		return
+	}
+
+	golden, err := readPNG(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Fatalf("golden image %s does not exist, run the test with -update to create it", path)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if golden.Bounds().Size() != img.Bounds().Size() {
+		t.Fatalf("golden image %s has size %v, but got %v", path, golden.Bounds().Size(), img.Bounds().Size())
+	}
+
+	count, diff := Diff(golden, img, opts.Threshold)
+	size := img.Bounds().Size()
+	if float64(count) <= opts.MaxDiff*float64(size.X*size.Y) {
+		os.Remove(actualPath)
+		os.Remove(diffPath)
+		return
+	}
+
+	if err := writePNG(actualPath, img); err != nil {
+		t.Error(err)
+	}
+	if err := writePNG(diffPath, diff); err != nil {
+		t.Error(err)
+	}
+	t.Errorf("image differs from golden image %s in %d pixels, see %s and %s", path, count, actualPath, diffPath)
+}
+
+// Diff returns the number of pixels whose perceptual difference exceeds the threshold, from 0 to 1,
+// and an image of the differences, where the expected image is faded and differing pixels are red.
+// The difference is measured in the YIQ colour space, which weighs the brightness more than the colour.
+// Both images must have the same size
+func Diff(expected, actual image.Image, threshold float64) (int, *image.RGBA) {
+	eb, ab := expected.Bounds(), actual.Bounds()
+	diff := image.NewRGBA(image.Rect(0, 0, eb.Dx(), eb.Dy()))
+
+	// the largest possible difference between 2 colours in the YIQ colour space
+	const maxDelta = 35215.0
+	limit := maxDelta * threshold * threshold
+
+	count := 0
+	for y := 0; y < eb.Dy(); y++ {
+		for x := 0; x < eb.Dx(); x++ {
+			e := expected.At(eb.Min.X+x, eb.Min.Y+y)
+			a := actual.At(ab.Min.X+x, ab.Min.Y+y)
+			if colourDelta(e, a) > limit {
+				count++
+				diff.SetRGBA(x, y, color.RGBA{255, 0, 0, 255})
+				continue
+			}
+
+			l := uint8(255 - (255-luma(e))/10)
+			diff.SetRGBA(x, y, color.RGBA{l, l, l, 255})
+		}
+	}
+	return count, diff
+}
+
+// colourDelta returns the squared perceptual difference between 2 colours, blended onto a white background
+func colourDelta(c1, c2 color.Color) float64 {
+	r1, g1, b1 := blend(c1)
+	r2, g2, b2 := blend(c2)
+
+	y := rgbToY(r1, g1, b1) - rgbToY(r2, g2, b2)
+	i := rgbToI(r1, g1, b1) - rgbToI(r2, g2, b2)
+	q := rgbToQ(r1, g1, b1) - rgbToQ(r2, g2, b2)
+	return 0.5053*y*y + 0.299*i*i + 0.1957*q*q
+}
+
+// blend returns the channels of the colour from 0 to 255, blended onto a white background
+func blend(c color.Color) (r, g, b float64) {
+	nc := color.NRGBAModel.Convert(c).(color.NRGBA)
+	a := float64(nc.A) / 255
+	return 255 + (float64(nc.R)-255)*a, 255 + (float64(nc.G)-255)*a, 255 + (float64(nc.B)-255)*a
+}
+
+func rgbToY(r, g, b float64) float64 { return r*0.29889531 + g*0.58662247 + b*0.11448223 }
+func rgbToI(r, g, b float64) float64 { return r*0.59597799 - g*0.27417610 - b*0.32180189 }
+func rgbToQ(r, g, b float64) float64 { return r*0.21147017 - g*0.52261711 + b*0.31114694 }
+
+// luma returns the brightness of the colour from 0 to 255
+func luma(c color.Color) float64 {
+	r, g, b := blend(c)
+	return rgbToY(r, g, b)
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return fmt.Errorf("rendertest: writing %s: %w", path, err)
+	}
+	return f.Close()
+}
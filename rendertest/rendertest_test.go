@@ -0,0 +1,79 @@
+package rendertest
+
+import (
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// recorder records failures instead of failing the test
+type recorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *recorder) Helper()                                   {}
+func (r *recorder) Error(args ...interface{})                 { r.failed = true }
+func (r *recorder) Errorf(format string, args ...interface{}) { r.failed = true }
+
+func solid(c color.RGBA) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for y := 0; y < 4; y++ {
+		for x := 0; x < 4; x++ {
+			img.SetRGBA(x, y, c)
+		}
+	}
+	return img
+}
+
+func TestDiff(t *testing.T) {
+	a := solid(color.RGBA{100, 100, 100, 255})
+	b := solid(color.RGBA{101, 100, 100, 255})
+	if count, _ := Diff(a, b, 0.1); count != 0 {
+		t.Errorf("Diff failed. Expected a small difference to be ignored, but got %d pixels", count)
+	}
+
+	b.SetRGBA(1, 2, color.RGBA{255, 0, 0, 255})
+	count, diff := Diff(a, b, 0.1)
+	if count != 1 {
+		t.Errorf("Diff failed. Expected %d pixel, but got %d", 1, count)
+	}
+	if c := diff.RGBAAt(1, 2); c != (color.RGBA{255, 0, 0, 255}) {
+		t.Errorf("Diff failed. Expected the differing pixel to be red, but got %v", c)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	dir := t.TempDir()
+	img := solid(color.RGBA{0, 0, 255, 255})
+	if err := writePNG(filepath.Join(dir, "scene.png"), img); err != nil {
+		t.Fatal(err)
+	}
+
+	r := &recorder{TB: t}
+	Golden(r, "scene", img, Options{Dir: dir})
+	if r.failed {
+		t.Errorf("Golden failed. Expected an equal image to match")
+	}
+
+	changed := solid(color.RGBA{0, 0, 255, 255})
+	changed.SetRGBA(0, 0, color.RGBA{255, 255, 0, 255})
+	Golden(r, "scene", changed, Options{Dir: dir})
+	if !r.failed {
+		t.Errorf("Golden failed. Expected a changed image to fail")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "scene.diff.png")); err != nil {
+		t.Errorf("Golden failed. Expected a diff image, but got %v", err)
+	}
+
+	r.failed = false
+	Golden(r, "scene", changed, Options{Dir: dir, MaxDiff: 0.1})
+	if r.failed {
+		t.Errorf("Golden failed. Expected 1 of 16 pixels to be within MaxDiff")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "scene.diff.png")); !os.IsNotExist(err) {
+		t.Errorf("Golden failed. Expected the diff image to be removed once the image matches")
+	}
+}
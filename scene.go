@@ -1,11 +1,14 @@
 package rebound
 
 // Mesh holds geometry data.
+// Source identifies the asset the mesh was loaded from, such as "helmet.gltf#0", which allows it to be saved in a scene
 type Mesh struct {
 	ID         uint32
 	Attributes []Attribute
 	Indices    []uint32
 	Material   *Material
+	Source     string
+	buffers    []uint32
 }
 
 // VertexCount returns the amount of vertices the Mesh contains.
@@ -13,20 +16,54 @@ func (m *Mesh) VertexCount() int {
 	return len(m.Indices)
 }
 
+// hasAttribute returns whether the mesh has an attribute of the type
+func (m *Mesh) hasAttribute(t AttributeType) bool {
+	for _, a := range m.Attributes {
+		if a.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
 // Material describes the look of a geometric object
+// EmissiveFactor is multiplied with the EmmisiveTexture, or is the emitted light when the material has no such texture
 type Material struct {
 	Transparent      bool
 	NormalTexture    *uint32
 	OcclusionTexture *uint32
 	EmmisiveTexture  *uint32
+	EmissiveFactor   [3]float32
 	PBRMetallicRoughness
 }
 
+// The texture units the textures of a [Material] are bound to while rendering
+const (
+	BaseColorUnit = iota
+	MetallicRoughnessUnit
+	NormalUnit
+	OcclusionUnit
+	EmissiveUnit
+)
+
+// textures returns the textures of the material by the unit they are bound to
+func (m *Material) textures() [5]*uint32 {
+	return [5]*uint32{
+		BaseColorUnit:         m.BaseColorTexture,
+		MetallicRoughnessUnit: m.MetallicRoughnessTexture,
+		NormalUnit:            m.NormalTexture,
+		OcclusionUnit:         m.OcclusionTexture,
+		EmissiveUnit:          m.EmmisiveTexture,
+	}
+}
+
 // PBRMetallicRoughness holds all data related to PBR such as roughness, basecolor and metallicness
+// The factors are multiplied with their texture, following the metallic-roughness model of glTF,
+// where the blue channel of the MetallicRoughnessTexture holds the metalness and its green channel the roughness
 type PBRMetallicRoughness struct {
 	BaseColor                [4]float32
 	BaseColorTexture         *uint32
-	MetallicFactor           int
-	RoughnessFactor          int
+	MetallicFactor           float32
+	RoughnessFactor          float32
 	MetallicRoughnessTexture *uint32
 }
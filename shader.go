@@ -2,9 +2,7 @@ package rebound
 
 import (
 	"fmt"
-	"strings"
 
-	"github.com/go-gl/gl/v4.1-core/gl"
 	"github.com/luukdegram/rebound/internal/thread"
 )
 
@@ -186,16 +184,16 @@ const (
 	` + "\x00"
 )
 
-var shaderIds []uint32
+var programIDs []uint32
 
 // Shader contains the logic to render a shader
 type Shader interface {
 	// Setup runs at the beginning of the renderer's update() function, before any entities are being rendered.
 	// The camera is provided to retreive its view and projection matrixes
 	Setup(Camera)
-	// Render runs while rendering each entity, the corresponding RenderComponent is provided in the Render runction.
+	// Render runs while rendering each entity, the corresponding RenderComponent and model matrix are provided in the Render runction.
 	// Within this function you can set entity specific shader options
-	Render(RenderComponent)
+	Render(RenderComponent, [16]float32)
 	// ID returns the shader's ID, this is generated by the NewShader function
 	ID() uint32
 }
@@ -212,34 +210,16 @@ type skyboxShader struct {
 	id uint32
 }
 
-//NewShader returns a new ShaderComponent by compiling the given vertexShader and fragmentShader
+//NewShader returns a new ShaderComponent by compiling the given vertexShader and fragmentShader using the current [Backend]
 //Returns an error if any of the shaders could not be compiled
 func NewShader(vertexShader, fragmentShader string) (id uint32, err error) {
 	err = thread.CallErr(func() error {
-		vID, err := compileShader(vertexShader, gl.VERTEX_SHADER)
+		id, err = CurrentBackend().CreateProgram(vertexShader, fragmentShader)
 		if err != nil {
 			return err
 		}
 
-		shaderIds = append(shaderIds, vID)
-
-		fID, err := compileShader(fragmentShader+"\x00", gl.FRAGMENT_SHADER)
-		if err != nil {
-			return err
-		}
-
-		shaderIds = append(shaderIds, fID)
-
-		id = gl.CreateProgram()
-		gl.AttachShader(id, vID)
-		gl.AttachShader(id, fID)
-
-		gl.LinkProgram(id)
-		gl.ValidateProgram(id)
-
-		gl.DetachShader(id, vID)
-		gl.DetachShader(id, fID)
-
+		programIDs = append(programIDs, id)
 		return nil
 	})
 
@@ -315,105 +295,79 @@ func (sb *skyboxShader) Setup(c Camera) {
 	LoadMat(sb, "view", NewViewMatrixNoTranslation(c))
 }
 
-// Render loads variables into the shader based on current RenderComponent
-func (bs *BasicShader) Render(rc RenderComponent) {
+// Render loads variables into the shader based on current RenderComponent and its model matrix
+func (bs *BasicShader) Render(rc RenderComponent, model [16]float32) {
 	// Set material
 	LoadVec3(bs, "material.specular", [3]float32{0.5, 0.5, 0.5})
 	LoadFloat(bs, "material.shininess", 64)
 
-	tmMat := NewTransformationMatrix(rc.Position, rc.Rotation, rc.Scale)
-	LoadMat(bs, "model", tmMat)
+	LoadMat(bs, "model", model)
 }
 
 // Render is an empty function. This is needed to comply to the Shader interface
-func (sb *skyboxShader) Render(rc RenderComponent) {}
+func (sb *skyboxShader) Render(rc RenderComponent, model [16]float32) {}
 
 //GetUniformLocation returns the location of the uniform given, returning the OpenGL id as an int32
+//Returns -1 when the current [Backend] has no uniform locations, such as the [SoftwareBackend]
 func GetUniformLocation(s Shader, name string) int32 {
-	return gl.GetUniformLocation(s.ID(), gl.Str(name+"\x00"))
+	if l, ok := CurrentBackend().(interface {
+		UniformLocation(program uint32, name string) int32
+	}); ok {
+		return l.UniformLocation(s.ID(), name)
+	}
+	return -1
 }
 
 //LoadFloat loads a uniform float into the shader
 func LoadFloat(s Shader, name string, value float32) {
-	loc := GetUniformLocation(s, name)
-	gl.Uniform1f(loc, value)
+	CurrentBackend().SetFloat(s.ID(), name, value)
 }
 
 // LoadInt loads an integer into the shader
 func LoadInt(s Shader, name string, value int) {
-	loc := GetUniformLocation(s, name)
-	gl.Uniform1i(loc, int32(value))
+	CurrentBackend().SetInt(s.ID(), name, int32(value))
 }
 
 //LoadVec3 loads a uniform Vector into the shader
 func LoadVec3(s Shader, name string, value [3]float32) {
-	loc := GetUniformLocation(s, name)
-	gl.Uniform3f(loc, value[0], value[1], value[2])
+	CurrentBackend().SetVec3(s.ID(), name, value)
 }
 
 // LoadVec4 loads a uniform Vector with 4 elements into the shader
 func LoadVec4(s Shader, name string, value [4]float32) {
-	loc := GetUniformLocation(s, name)
-	gl.Uniform4f(loc, value[0], value[1], value[2], value[3])
+	CurrentBackend().SetVec4(s.ID(), name, value)
 }
 
 //LoadBool loads a boolean into the shader
 func LoadBool(s Shader, name string, value bool) {
-	loc := GetUniformLocation(s, name)
 	var float float32
 	if value {
 		float = 1
 	}
-	gl.Uniform1f(loc, float)
+	CurrentBackend().SetFloat(s.ID(), name, float)
 }
 
 //LoadMat loads a matrix into the shader
 func LoadMat(s Shader, name string, value [16]float32) {
-	loc := GetUniformLocation(s, name)
-	gl.UniformMatrix4fv(loc, 1, false, &value[0])
+	CurrentBackend().SetMat4(s.ID(), name, value)
 }
 
 //startHader starts the shader program
 func startShader(s Shader) {
-	gl.UseProgram(s.ID())
+	CurrentBackend().UseProgram(s.ID())
 }
 
 //stopShader stops the current shader program
 func stopShader() {
-	gl.UseProgram(0)
+	CurrentBackend().UseProgram(0)
 }
 
 //CleanUpShaders deletes the program
 func CleanUpShaders() {
 	thread.Call(func() {
-		for _, id := range shaderIds {
-			gl.DeleteProgram(id)
-		}
-
-		for _, id := range shaderIds {
-			gl.DeleteShader(id)
+		for _, id := range programIDs {
+			CurrentBackend().DeleteProgram(id)
 		}
+		programIDs = nil
 	})
 }
-
-func compileShader(source string, shaderType uint32) (uint32, error) {
-	shader := gl.CreateShader(shaderType)
-
-	csources, free := gl.Strs(source)
-	gl.ShaderSource(shader, 1, csources, nil)
-	free()
-	gl.CompileShader(shader)
-
-	var status int32
-	gl.GetShaderiv(shader, gl.COMPILE_STATUS, &status)
-	if status == gl.FALSE {
-		var logLength int32
-		gl.GetShaderiv(shader, gl.INFO_LOG_LENGTH, &logLength)
-
-		log := strings.Repeat("\x00", int(logLength+1))
-		gl.GetShaderInfoLog(shader, logLength, nil, gl.Str(log))
-
-		return 0, fmt.Errorf("failed to compile %v: %v", source, log)
-	}
-	return shader, nil
-}
@@ -0,0 +1,371 @@
+package rebound
+
+import (
+	"image"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// softwareShader emulates the vertex and fragment shader of a program for the [SoftwareBackend]
+type softwareShader interface {
+	// vertex returns the position of the vertex in clip space and the values that are interpolated for its fragments
+	vertex(u uniforms, in vertexInput) (mgl32.Vec4, varyings)
+	// fragment returns the colour of a fragment, or false when the fragment is discarded
+	fragment(u uniforms, in varyings, s sampler) (mgl32.Vec4, bool)
+}
+
+// softwareShaders holds the emulated shaders by the sources of the shader they emulate, see shaderKey
+var softwareShaders = map[string]softwareShader{
+	shaderKey(defaultVShader, defaultFShader): basicSoftwareShader{},
+	shaderKey(cubeMapVShader, cubeMapFShader): skyboxSoftwareShader{},
+	shaderKey(pbrVShader, pbrFShader):         pbrSoftwareShader{},
+}
+
+// varyings holds the values a vertex passes on to its fragments
+type varyings [16]float32
+
+// vec3 returns 3 values starting at offset
+func (v *varyings) vec3(offset int) mgl32.Vec3 {
+	return mgl32.Vec3{v[offset], v[offset+1], v[offset+2]}
+}
+
+// setVec3 sets 3 values starting at offset
+func (v *varyings) setVec3(offset int, value mgl32.Vec3) {
+	copy(v[offset:], value[:])
+}
+
+// uniforms holds the uniform values of a program by their name, unset uniforms are zero
+type uniforms map[string]any
+
+func (u uniforms) float(name string) float32 {
+	v, _ := u[name].(float32)
+	return v
+}
+
+func (u uniforms) int(name string) int {
+	v, _ := u[name].(int32)
+	return int(v)
+}
+
+func (u uniforms) vec3(name string) mgl32.Vec3 {
+	v, _ := u[name].(mgl32.Vec3)
+	return v
+}
+
+func (u uniforms) vec4(name string) mgl32.Vec4 {
+	v, _ := u[name].(mgl32.Vec4)
+	return v
+}
+
+func (u uniforms) mat4(name string) mgl32.Mat4 {
+	v, _ := u[name].(mgl32.Mat4)
+	return v
+}
+
+// vertexInput reads the attributes of a single vertex of a mesh
+type vertexInput struct {
+	mesh  *softwareMesh
+	index int
+}
+
+// attribute returns the attribute of the vertex, where missing elements default to 0, 0, 0, 1 like OpenGL
+func (in vertexInput) attribute(t AttributeType) mgl32.Vec4 {
+	v := mgl32.Vec4{0, 0, 0, 1}
+	a, exists := in.mesh.attributes[t]
+	if !exists {
+		return v
+	}
+	start := in.index * a.Size
+	for i := 0; i < a.Size && i < 4 && start+i < len(a.Data); i++ {
+		v[i] = a.Data[start+i]
+	}
+	return v
+}
+
+// sampler samples the textures bound to the units of a [SoftwareBackend]
+type sampler struct {
+	b *SoftwareBackend
+}
+
+// missingTexel is returned when sampling a unit without a texture, like an incomplete texture in OpenGL
+var missingTexel = mgl32.Vec4{0, 0, 0, 1}
+
+// texture samples the texture bound to the unit using linear filtering, repeating the texture
+func (s sampler) texture(unit int, uv mgl32.Vec2) mgl32.Vec4 {
+	faces := s.b.textures[s.b.units[unit]]
+	if len(faces) != 1 {
+		return missingTexel
+	}
+	return bilinear(faces[0], uv[0], uv[1], true)
+}
+
+// cube samples the cube map bound to the unit in the given direction, see the OpenGL specification for the face selection
+func (s sampler) cube(unit int, dir mgl32.Vec3) mgl32.Vec4 {
+	faces := s.b.textures[s.b.units[unit]]
+	if len(faces) != 6 {
+		return missingTexel
+	}
+
+	x, y, z := dir[0], dir[1], dir[2]
+	ax, ay, az := abs(x), abs(y), abs(z)
+	var face int
+	var sc, tc, ma float32
+	switch {
+	case ax >= ay && ax >= az && x >= 0:
+		face, sc, tc, ma = 0, -z, -y, ax
+	case ax >= ay && ax >= az:
+		face, sc, tc, ma = 1, z, -y, ax
+	case ay >= az && y >= 0:
+		face, sc, tc, ma = 2, x, z, ay
+	case ay >= az:
+		face, sc, tc, ma = 3, x, -z, ay
+	case z >= 0:
+		face, sc, tc, ma = 4, x, -y, az
+	default:
+		face, sc, tc, ma = 5, -x, -y, az
+	}
+	if ma == 0 {
+		return missingTexel
+	}
+	return bilinear(faces[face], (sc/ma+1)/2, (tc/ma+1)/2, false)
+}
+
+// bilinear samples the image at the texture coordinates, where 0, 0 is the first pixel of the image.
+// Coordinates outside of the image repeat the image or are clamped to its edge
+func bilinear(img *image.RGBA, u, v float32, repeat bool) mgl32.Vec4 {
+	w, h := img.Rect.Dx(), img.Rect.Dy()
+	if w == 0 || h == 0 {
+		return missingTexel
+	}
+
+	x, y := u*float32(w)-0.5, v*float32(h)-0.5
+	x0, y0 := float32(math.Floor(float64(x))), float32(math.Floor(float64(y)))
+	fx, fy := x-x0, y-y0
+
+	texel := func(tx, ty int) mgl32.Vec4 {
+		if repeat {
+			tx, ty = ((tx%w)+w)%w, ((ty%h)+h)%h
+		} else {
+			tx, ty = min(max(tx, 0), w-1), min(max(ty, 0), h-1)
+		}
+		o := img.PixOffset(img.Rect.Min.X+tx, img.Rect.Min.Y+ty)
+		p := img.Pix[o : o+4 : o+4]
+		return mgl32.Vec4{float32(p[0]) / 255, float32(p[1]) / 255, float32(p[2]) / 255, float32(p[3]) / 255}
+	}
+
+	ix, iy := int(x0), int(y0)
+	top := texel(ix, iy).Mul(1 - fx).Add(texel(ix+1, iy).Mul(fx))
+	bottom := texel(ix, iy+1).Mul(1 - fx).Add(texel(ix+1, iy+1).Mul(fx))
+	return top.Mul(1 - fy).Add(bottom.Mul(fy))
+}
+
+func abs(v float32) float32 {
+	return float32(math.Abs(float64(v)))
+}
+
+// normalize returns the unit vector, or the zero vector when its length is 0
+func normalize(v mgl32.Vec3) mgl32.Vec3 {
+	if l := v.Len(); l > 0 {
+		return v.Mul(1 / l)
+	}
+	return v
+}
+
+// reflect reflects the incident vector around the normal, like reflect in GLSL
+func reflect(i, n mgl32.Vec3) mgl32.Vec3 {
+	return i.Sub(n.Mul(2 * n.Dot(i)))
+}
+
+// basicSoftwareShader emulates the shader of the [BasicShader]
+// The varyings hold the position of the fragment at 0, its normal at 3 and the texture coordinates at 6
+type basicSoftwareShader struct{}
+
+func (basicSoftwareShader) vertex(u uniforms, in vertexInput) (mgl32.Vec4, varyings) {
+	var out varyings
+	pos := u.mat4("model").Mul4x1(in.attribute(POSITION).Vec3().Vec4(1))
+	out.setVec3(0, pos.Vec3())
+	out.setVec3(3, in.attribute(NORMALS).Vec3())
+	uv := in.attribute(TEXCOORDS0)
+	out[6], out[7] = uv[0], uv[1]
+
+	return u.mat4("projection").Mul4(u.mat4("view")).Mul4x1(pos.Vec3().Vec4(1)), out
+}
+
+func (basicSoftwareShader) fragment(u uniforms, in varyings, s sampler) (mgl32.Vec4, bool) {
+	tex := s.texture(u.int("material.diffuse"), mgl32.Vec2{in[6], in[7]})
+	if tex[3] < 0.5 {
+		return mgl32.Vec4{}, false
+	}
+
+	fragPos := in.vec3(0)
+	norm := normalize(in.vec3(3))
+	viewDir := normalize(u.vec3("viewPos").Sub(fragPos))
+	specular, shininess := u.vec3("material.specular"), u.float("material.shininess")
+
+	// shade returns the light of a single light source coming from the given direction
+	shade := func(prefix string, lightDir mgl32.Vec3) mgl32.Vec3 {
+		diff := max(norm.Dot(lightDir), 0)
+		reflectDir := reflect(lightDir.Mul(-1), norm)
+		spec := float32(math.Pow(float64(max(viewDir.Dot(reflectDir), 0)), float64(shininess)))
+
+		ambient := mul(u.vec3(prefix+"ambient"), tex.Vec3())
+		diffuse := mul(u.vec3(prefix+"diffuse"), tex.Vec3()).Mul(diff)
+		return ambient.Add(diffuse).Add(mul(u.vec3(prefix+"specular"), specular.Mul(spec)))
+	}
+
+	light := shade("light.", normalize(u.vec3("light.direction").Mul(-1)))
+	for i := 0; i < min(u.int("amountLights"), len(pointLightPrefixes)); i++ {
+		prefix := pointLightPrefixes[i]
+		position := u.vec3(prefix + "position")
+		distance := position.Sub(fragPos).Len()
+		attenuation := 1 / (u.float(prefix+"constant") + u.float(prefix+"linear")*distance + u.float(prefix+"quadratic")*distance*distance)
+		light = light.Add(shade(prefix, normalize(position.Sub(fragPos))).Mul(attenuation))
+	}
+
+	return light.Vec4(1), true
+}
+
+// pointLightPrefixes holds the uniform prefix of each point light the basic shader supports
+var pointLightPrefixes = [...]string{"pointLights[0].", "pointLights[1].", "pointLights[2].", "pointLights[3]."}
+
+// mul multiplies 2 vectors per element
+func mul(a, b mgl32.Vec3) mgl32.Vec3 {
+	return mgl32.Vec3{a[0] * b[0], a[1] * b[1], a[2] * b[2]}
+}
+
+// pbrSoftwareShader emulates the shader of the [PBRShader]
+// The varyings hold the position of the fragment at 0, its normal at 3, the texture coordinates at 6 and the tangent at 8
+type pbrSoftwareShader struct{}
+
+func (pbrSoftwareShader) vertex(u uniforms, in vertexInput) (mgl32.Vec4, varyings) {
+	var out varyings
+	model := u.mat4("model")
+	pos := model.Mul4x1(in.attribute(POSITION).Vec3().Vec4(1))
+	out.setVec3(0, pos.Vec3())
+	out.setVec3(3, u.mat4("normalMatrix").Mat3().Mul3x1(in.attribute(NORMALS).Vec3()))
+	uv := in.attribute(TEXCOORDS0)
+	out[6], out[7] = uv[0], uv[1]
+	tangent := in.attribute(TANGENTS)
+	out.setVec3(8, model.Mat3().Mul3x1(tangent.Vec3()))
+	out[11] = tangent[3]
+
+	return u.mat4("projection").Mul4(u.mat4("view")).Mul4x1(pos.Vec3().Vec4(1)), out
+}
+
+func (pbrSoftwareShader) fragment(u uniforms, in varyings, s sampler) (mgl32.Vec4, bool) {
+	uv := mgl32.Vec2{in[6], in[7]}
+	has := func(texture string) bool { return u.float("material.has"+texture+"Texture") != 0 }
+
+	base := u.vec4("material.baseColor")
+	if has("BaseColor") {
+		tex := s.texture(u.int("material.baseColorTexture"), uv)
+		base = mul4(base, toLinear(tex.Vec3()).Vec4(tex[3]))
+	}
+	if base[3] < 0.5 {
+		return mgl32.Vec4{}, false
+	}
+
+	metallic, roughness := u.float("material.metallic"), u.float("material.roughness")
+	if has("MetallicRoughness") {
+		mr := s.texture(u.int("material.metallicRoughnessTexture"), uv)
+		roughness *= mr[1]
+		metallic *= mr[2]
+	}
+	metallic, roughness = mgl32.Clamp(metallic, 0, 1), mgl32.Clamp(roughness, 0.04, 1)
+
+	fragPos := in.vec3(0)
+	n := normalize(in.vec3(3))
+	if has("Normal") {
+		tangent := in.vec3(8)
+		t := normalize(tangent.Sub(n.Mul(n.Dot(tangent))))
+		b := n.Cross(t).Mul(in[11])
+		tex := s.texture(u.int("material.normalTexture"), uv).Vec3().Mul(2).Sub(mgl32.Vec3{1, 1, 1})
+		n = normalize(mgl32.Mat3FromCols(t, b, n).Mul3x1(tex))
+	}
+	v := normalize(u.vec3("viewPos").Sub(fragPos))
+
+	albedo := base.Vec3()
+	f0 := mgl32.Vec3{0.04, 0.04, 0.04}.Mul(1 - metallic).Add(albedo.Mul(metallic))
+	brdf := func(l, radiance mgl32.Vec3) mgl32.Vec3 {
+		return cookTorrance(n, v, l, radiance, albedo, metallic, roughness, f0)
+	}
+
+	colour := brdf(normalize(u.vec3("light.direction").Mul(-1)), u.vec3("light.colour"))
+	for i := 0; i < min(u.int("amountLights"), len(pointLightPrefixes)); i++ {
+		prefix := pointLightPrefixes[i]
+		l := u.vec3(prefix + "position").Sub(fragPos)
+		distance := l.Len()
+		attenuation := 1 / (u.float(prefix+"constant") + u.float(prefix+"linear")*distance + u.float(prefix+"quadratic")*distance*distance)
+		colour = colour.Add(brdf(normalize(l), u.vec3(prefix+"colour").Mul(attenuation)))
+	}
+
+	occlusion := float32(1)
+	if has("Occlusion") {
+		occlusion = s.texture(u.int("material.occlusionTexture"), uv)[0]
+	}
+	colour = colour.Add(mul(u.vec3("ambient"), albedo.Mul(1-metallic).Add(f0)).Mul(occlusion))
+
+	emissive := u.vec3("material.emissive")
+	if has("Emissive") {
+		emissive = mul(emissive, toLinear(s.texture(u.int("material.emissiveTexture"), uv).Vec3()))
+	}
+	colour = colour.Add(emissive)
+
+	// tone map and gamma correct the result
+	for i, c := range colour {
+		colour[i] = float32(math.Pow(float64(c/(c+1)), 1/2.2))
+	}
+	return colour.Vec4(1), true
+}
+
+// cookTorrance returns the light reflected towards the viewer, see BRDF in the shader of the [PBRShader]
+func cookTorrance(n, v, l, radiance, albedo mgl32.Vec3, metallic, roughness float32, f0 mgl32.Vec3) mgl32.Vec3 {
+	h := normalize(v.Add(l))
+	nDotL := max(n.Dot(l), 0)
+	nDotV := max(n.Dot(v), 0.0001)
+	nDotH := max(n.Dot(h), 0)
+
+	a2 := roughness * roughness * roughness * roughness
+	d := nDotH*nDotH*(a2-1) + 1
+	distribution := a2 / (math.Pi * d * d)
+
+	k := (roughness + 1) * (roughness + 1) / 8
+	geometry := nDotV / (nDotV*(1-k) + k) * nDotL / (nDotL*(1-k) + k)
+
+	fresnel := float32(math.Pow(float64(mgl32.Clamp(1-max(h.Dot(v), 0), 0, 1)), 5))
+	f := f0.Add(mgl32.Vec3{1, 1, 1}.Sub(f0).Mul(fresnel))
+
+	specular := f.Mul(distribution * geometry / (4*nDotV*nDotL + 0.0001))
+	kD := mgl32.Vec3{1, 1, 1}.Sub(f).Mul(1 - metallic)
+	return mul(mul(kD, albedo).Mul(1/math.Pi).Add(specular), radiance).Mul(nDotL)
+}
+
+// toLinear converts a colour from sRGB to linear, like the shader of the [PBRShader]
+func toLinear(c mgl32.Vec3) mgl32.Vec3 {
+	for i := range c {
+		c[i] = float32(math.Pow(float64(c[i]), 2.2))
+	}
+	return c
+}
+
+// mul4 multiplies 2 vectors with 4 elements per element
+func mul4(a, b mgl32.Vec4) mgl32.Vec4 {
+	return mgl32.Vec4{a[0] * b[0], a[1] * b[1], a[2] * b[2], a[3] * b[3]}
+}
+
+// skyboxSoftwareShader emulates the shader of a [Skybox], the varyings hold the direction of the fragment
+type skyboxSoftwareShader struct{}
+
+func (skyboxSoftwareShader) vertex(u uniforms, in vertexInput) (mgl32.Vec4, varyings) {
+	var out varyings
+	pos := in.attribute(POSITION).Vec3()
+	out.setVec3(0, pos)
+
+	clip := u.mat4("projection").Mul4(u.mat4("view")).Mul4x1(pos.Vec4(1))
+	return mgl32.Vec4{clip[0], clip[1], clip[3], clip[3]}, out
+}
+
+func (skyboxSoftwareShader) fragment(u uniforms, in varyings, s sampler) (mgl32.Vec4, bool) {
+	return s.cube(u.int("skybox"), in.vec3(0)), true
+}
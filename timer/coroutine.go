@@ -0,0 +1,124 @@
+package timer
+
+import (
+	"runtime"
+	"time"
+)
+
+// Co is a coroutine run by a [Scheduler]. Its function runs on its own goroutine,
+// but only while the Scheduler is updated, so it can wait across multiple frames as if it were a sequence of steps
+type Co struct {
+	h      Handle
+	s      *Scheduler
+	fn     func(co *Co)
+	resume chan bool
+	yield  chan bool
+	wake   float64
+	until  func() bool
+	frames int
+	start  bool
+}
+
+// Start runs fn as a coroutine, starting at the next update of the Scheduler
+func (s *Scheduler) Start(fn func(co *Co)) *Handle {
+	co := &Co{
+		s:      s,
+		fn:     fn,
+		resume: make(chan bool),
+		yield:  make(chan bool),
+	}
+	return s.schedule(co)
+}
+
+func (co *Co) handle() *Handle {
+	return &co.h
+}
+
+// run resumes the coroutine when it is done waiting and blocks until it waits again or finishes.
+// Returns true once the coroutine has finished
+func (co *Co) run(now float64) bool {
+	if co.h.cancelled.Load() {
+		co.stop()
+		return true
+	}
+
+	if co.frames > 0 {
+		co.frames--
+		return false
+	}
+	if now < co.wake || (co.until != nil && !co.until()) {
+		return false
+	}
+	co.until = nil
+
+	if !co.start {
+		co.start = true
+		go co.main()
+	}
+	co.resume <- true
+	return <-co.yield
+}
+
+// main runs the function of the coroutine on its own goroutine
+func (co *Co) main() {
+	finished := false
+	defer func() {
+		co.yield <- finished
+	}()
+
+	if !<-co.resume {
+		return
+	}
+	co.fn(co)
+	finished = true
+}
+
+// stop unwinds a coroutine that is waiting
+func (co *Co) stop() {
+	if co.start {
+		co.resume <- false
+		<-co.yield
+	}
+}
+
+// park hands control back to the Scheduler until the coroutine is resumed
+func (co *Co) park() {
+	co.yield <- false
+	if !<-co.resume {
+		runtime.Goexit()
+	}
+}
+
+// Now returns the time in seconds that passed within the Scheduler
+func (co *Co) Now() float64 {
+	return co.s.Now()
+}
+
+// Yield waits until the next update of the Scheduler
+func (co *Co) Yield() {
+	co.park()
+}
+
+// WaitFrames waits for the given number of updates of the Scheduler
+func (co *Co) WaitFrames(n int) {
+	if n <= 0 {
+		return
+	}
+	co.frames = n - 1
+	co.park()
+}
+
+// Wait waits until d has passed
+func (co *Co) Wait(d time.Duration) {
+	co.wake = co.s.Now() + d.Seconds()
+	co.park()
+}
+
+// WaitUntil waits until the condition is true, the condition is checked once every update
+func (co *Co) WaitUntil(cond func() bool) {
+	if cond() {
+		return
+	}
+	co.until = cond
+	co.park()
+}
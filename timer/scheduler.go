@@ -0,0 +1,143 @@
+// Package timer provides a scheduler system that runs callbacks and coroutines based on the time passed within the world
+package timer
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/luukdegram/rebound/ecs"
+)
+
+// Handle controls a scheduled callback or coroutine
+type Handle struct {
+	cancelled atomic.Bool
+	done      atomic.Bool
+}
+
+// Cancel stops the callback or coroutine from running again.
+// A cancelled coroutine stops at the next point it waits, running its deferred functions
+func (h *Handle) Cancel() {
+	h.cancelled.Store(true)
+}
+
+// Done returns true once the callback or coroutine has finished or was cancelled
+func (h *Handle) Done() bool {
+	return h.done.Load()
+}
+
+// task is a callback or coroutine waiting to be run
+type task interface {
+	// run is called every update and returns true once the task is finished
+	run(now float64) bool
+	handle() *Handle
+}
+
+// Scheduler is a [ecs.System] that runs callbacks after a delay or at an interval, and steps coroutines every update.
+// Time only passes while the Scheduler is updated, so it respects the time scale and pausing of the world.
+// It does not declare its component access, so the callbacks never run in parallel with other systems
+type Scheduler struct {
+	ecs.BaseSystem
+	m     sync.Mutex
+	now   float64
+	tasks []task
+}
+
+// NewScheduler returns a new Scheduler
+func NewScheduler() *Scheduler {
+	return &Scheduler{BaseSystem: ecs.NewBaseSystem()}
+}
+
+// After runs fn once after d has passed
+func (s *Scheduler) After(d time.Duration, fn func()) *Handle {
+	return s.schedule(&timerTask{fn: fn, due: s.Now() + d.Seconds()})
+}
+
+// Every runs fn every time d has passed, until it is cancelled.
+// When more than d passed since the last update, fn runs once for every interval that passed
+func (s *Scheduler) Every(d time.Duration, fn func()) *Handle {
+	interval := d.Seconds()
+	return s.schedule(&timerTask{fn: fn, due: s.Now() + interval, interval: interval})
+}
+
+// Now returns the time in seconds that passed within the Scheduler
+func (s *Scheduler) Now() float64 {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.now
+}
+
+// schedule adds the task, which runs from the next update onwards
+func (s *Scheduler) schedule(t task) *Handle {
+	s.m.Lock()
+	s.tasks = append(s.tasks, t)
+	s.m.Unlock()
+	return t.handle()
+}
+
+// Update advances the time of the Scheduler and runs every callback and coroutine that is due
+func (s *Scheduler) Update(dt float64) {
+	s.m.Lock()
+	s.now += dt
+	now := s.now
+	tasks := s.tasks
+	s.tasks = nil
+	s.m.Unlock()
+
+	remaining := tasks[:0]
+	for _, t := range tasks {
+		if !t.run(now) {
+			remaining = append(remaining, t)
+			continue
+		}
+		t.handle().done.Store(true)
+	}
+
+	// Keep the tasks that were scheduled while running the others
+	s.m.Lock()
+	s.tasks = append(remaining, s.tasks...)
+	s.m.Unlock()
+}
+
+// Shutdown cancels every coroutine that is still running
+func (s *Scheduler) Shutdown() {
+	s.m.Lock()
+	tasks := s.tasks
+	s.tasks = nil
+	s.m.Unlock()
+
+	for _, t := range tasks {
+		if co, ok := t.(*Co); ok {
+			co.stop()
+		}
+		t.handle().done.Store(true)
+	}
+}
+
+// Name returns the name of the Scheduler
+func (s *Scheduler) Name() string {
+	return "Scheduler"
+}
+
+// timerTask runs a callback once or at an interval
+type timerTask struct {
+	h        Handle
+	fn       func()
+	due      float64
+	interval float64
+}
+
+func (t *timerTask) handle() *Handle {
+	return &t.h
+}
+
+func (t *timerTask) run(now float64) bool {
+	for !t.h.cancelled.Load() && now >= t.due {
+		t.fn()
+		if t.interval <= 0 {
+			return true
+		}
+		t.due += t.interval
+	}
+	return t.h.cancelled.Load()
+}
@@ -0,0 +1,112 @@
+package timer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAfter(t *testing.T) {
+	s := NewScheduler()
+	calls := 0
+	h := s.After(time.Second, func() { calls++ })
+
+	s.Update(0.5)
+	if calls != 0 || h.Done() {
+		t.Errorf("After failed. Expected no calls after 0.5 seconds, but got %d", calls)
+	}
+
+	s.Update(0.5)
+	s.Update(1)
+	if calls != 1 || !h.Done() {
+		t.Errorf("After failed. Expected 1 call, but got %d", calls)
+	}
+}
+
+func TestEvery(t *testing.T) {
+	s := NewScheduler()
+	calls := 0
+	h := s.Every(time.Second, func() { calls++ })
+
+	s.Update(1)
+	s.Update(2.5)
+	if calls != 3 {
+		t.Errorf("Every failed. Expected 3 calls, but got %d", calls)
+	}
+
+	h.Cancel()
+	s.Update(1)
+	if calls != 3 || !h.Done() {
+		t.Errorf("Cancel failed. Expected 3 calls, but got %d", calls)
+	}
+}
+
+func TestScheduleWhileRunning(t *testing.T) {
+	s := NewScheduler()
+	calls := 0
+	s.After(0, func() {
+		s.After(time.Second, func() { calls++ })
+	})
+
+	s.Update(0)
+	s.Update(1)
+	if calls != 1 {
+		t.Errorf("After failed. Expected nested callback to run, but got %d calls", calls)
+	}
+}
+
+func TestCoroutine(t *testing.T) {
+	s := NewScheduler()
+	var steps []string
+	ready := false
+	h := s.Start(func(co *Co) {
+		steps = append(steps, "start")
+		co.Wait(time.Second)
+		steps = append(steps, "waited")
+		co.WaitUntil(func() bool { return ready })
+		steps = append(steps, "ready")
+		co.WaitFrames(2)
+		steps = append(steps, "frames")
+	})
+
+	s.Update(0)
+	s.Update(0.5)
+	if len(steps) != 1 {
+		t.Fatalf("Wait failed. Expected 1 step, but got %v", steps)
+	}
+
+	s.Update(0.5)
+	s.Update(1)
+	if len(steps) != 2 {
+		t.Fatalf("WaitUntil failed. Expected 2 steps, but got %v", steps)
+	}
+
+	ready = true
+	s.Update(0)
+	s.Update(0)
+	if len(steps) != 3 || h.Done() {
+		t.Fatalf("WaitFrames failed. Expected 3 steps, but got %v", steps)
+	}
+
+	s.Update(0)
+	if len(steps) != 4 || !h.Done() {
+		t.Errorf("Coroutine failed. Expected 4 steps and to be done, but got %v", steps)
+	}
+}
+
+func TestCancelCoroutine(t *testing.T) {
+	s := NewScheduler()
+	deferred := false
+	reached := false
+	h := s.Start(func(co *Co) {
+		defer func() { deferred = true }()
+		co.Wait(time.Second)
+		reached = true
+	})
+
+	s.Update(0)
+	h.Cancel()
+	s.Update(2)
+	if reached || !deferred || !h.Done() {
+		t.Errorf("Cancel failed. Expected coroutine to stop and run its deferred functions")
+	}
+}
@@ -0,0 +1,185 @@
+package rebound
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/luukdegram/rebound/ecs"
+)
+
+const (
+	// TransformComponentName is the name of a Transform
+	TransformComponentName = "Transform"
+)
+
+// Transform holds the position, rotation and scale of an entity relative to its parent.
+// The world matrix combines the transforms of the entity and all of its ancestors, and is updated by the [TransformSystem]
+type Transform struct {
+	Position [3]float32
+	Rotation mgl32.Quat
+	Scale    [3]float32
+
+	local       [16]float32
+	world       [16]float32
+	previous    [16]float32
+	parentWorld [16]float32
+	trs         trs
+	computed    bool
+}
+
+// trs holds the local values a Transform matrix was computed from
+type trs struct {
+	position [3]float32
+	rotation mgl32.Quat
+	scale    [3]float32
+}
+
+// NewTransform returns a Transform at the origin without any rotation and a scale of 1
+func NewTransform() *Transform {
+	return &Transform{
+		Rotation: mgl32.QuatIdent(),
+		Scale:    [3]float32{1, 1, 1},
+	}
+}
+
+// Name returns the Transform name
+func (t *Transform) Name() string {
+	return TransformComponentName
+}
+
+// Local returns the transformation matrix of the Transform relative to its parent
+func (t *Transform) Local() [16]float32 {
+	return ComposeMatrix(t.Position, t.Rotation, t.Scale)
+}
+
+// SetLocal sets the position, rotation and scale of the Transform from a transformation matrix
+func (t *Transform) SetLocal(m [16]float32) {
+	t.Position, t.Rotation, t.Scale = DecomposeMatrix(m)
+}
+
+// Euler returns the rotation as angles around the X, Y and Z axis in degrees, see [QuatToEuler]
+func (t *Transform) Euler() [3]float32 {
+	return QuatToEuler(t.Rotation)
+}
+
+// SetEuler sets the rotation from angles around the X, Y and Z axis in degrees, see [EulerToQuat]
+func (t *Transform) SetEuler(rot [3]float32) {
+	t.Rotation = EulerToQuat(rot)
+}
+
+// LookAt rotates the Transform so its front, which faces -Z, points towards the target
+func (t *Transform) LookAt(target, up [3]float32) {
+	t.Rotation = LookAt(t.Position, target, up)
+}
+
+// World returns the world matrix as computed during the last update of the [TransformSystem].
+// Before the first update it returns the local matrix
+func (t *Transform) World() [16]float32 {
+	if !t.computed {
+		return t.Local()
+	}
+	return t.world
+}
+
+// Interpolated returns the world matrix between the previous and the last update of the [TransformSystem],
+// where alpha 0 returns the previous and 1 the last world matrix. This smooths movement when rendering between fixed updates
+func (t *Transform) Interpolated(alpha float64) [16]float32 {
+	if !t.computed || alpha >= 1 || t.previous == t.world {
+		return t.World()
+	}
+
+	fromPos, fromRot, fromScale := DecomposeMatrix(t.previous)
+	toPos, toRot, toScale := DecomposeMatrix(t.world)
+	a := float32(alpha)
+	return ComposeMatrix(
+		mgl32.Vec3(fromPos).Add(mgl32.Vec3(toPos).Sub(fromPos).Mul(a)),
+		Slerp(fromRot, toRot, a),
+		mgl32.Vec3(fromScale).Add(mgl32.Vec3(toScale).Sub(fromScale).Mul(a)),
+	)
+}
+
+// update recomputes the world matrix when the local values or the world matrix of the parent changed since the last update.
+// The world matrix of the last update is kept to interpolate between both.
+// Returns true when the world matrix was recomputed
+func (t *Transform) update(parent [16]float32) bool {
+	t.previous = t.world
+	current := trs{t.Position, t.Rotation, t.Scale}
+	if t.computed && current == t.trs && parent == t.parentWorld {
+		return false
+	}
+
+	if !t.computed || current != t.trs {
+		t.local = ComposeMatrix(t.Position, t.Rotation, t.Scale)
+		t.trs = current
+	}
+	t.parentWorld = parent
+	t.world = mgl32.Mat4(parent).Mul4(t.local)
+	if !t.computed {
+		t.previous = t.world
+	}
+	t.computed = true
+	return true
+}
+
+// TransformSystem propagates the world matrix of each [Transform] through the children of its entity.
+// Only transforms whose local values or ancestors changed since the last update are recomputed.
+// Entities without a Transform pass the world matrix of their parent on to their children
+type TransformSystem struct {
+	ecs.BaseSystem
+}
+
+// NewTransformSystem returns a new TransformSystem
+func NewTransformSystem() *TransformSystem {
+	return &TransformSystem{ecs.NewBaseSystem()}
+}
+
+// Update propagates the world matrices, starting at every entity that has no ancestor within the system
+func (ts *TransformSystem) Update(dt float64) {
+	entities := ts.Entities()
+	for _, e := range entities {
+		if !hasAncestorIn(e, entities) {
+			propagate(e, mgl32.Ident4())
+		}
+	}
+}
+
+// hasAncestorIn returns true if any of the ancestors of the entity is part of the given entities
+func hasAncestorIn(e *ecs.Entity, entities map[ecs.EntityID]*ecs.Entity) bool {
+	for p := e.Parent(); p != nil; p = p.Parent() {
+		if _, exists := entities[p.ID()]; exists {
+			return true
+		}
+	}
+	return false
+}
+
+// propagate updates the Transform of the entity using the world matrix of its parent and continues with its children
+func propagate(e *ecs.Entity, parent [16]float32) {
+	world := parent
+	if t, ok := ecs.Get[*Transform](e); ok {
+		t.update(parent)
+		world = t.world
+	}
+
+	for _, c := range e.Children() {
+		propagate(c, world)
+	}
+}
+
+// Query returns the components an entity requires to be transformed
+func (ts *TransformSystem) Query() ecs.Query {
+	return ecs.Query{TransformComponentName}
+}
+
+// Stage returns the stage of the transform system, which runs after the systems that move entities
+func (ts *TransformSystem) Stage() ecs.Stage {
+	return ecs.StagePostUpdate
+}
+
+// Access returns the components the transform system reads and writes
+func (ts *TransformSystem) Access() ecs.Access {
+	return ecs.Access{Writes: []string{TransformComponentName}}
+}
+
+// Name returns the name of the transform system
+func (ts *TransformSystem) Name() string {
+	return "TransformSystem"
+}
@@ -0,0 +1,77 @@
+package rebound
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/luukdegram/rebound/ecs"
+)
+
+func TestTransformPropagation(t *testing.T) {
+	w := ecs.NewWorld()
+	ts := NewTransformSystem()
+	if err := w.AddSystems(ts); err != nil {
+		t.Fatal(err)
+	}
+
+	parent := NewTransform()
+	parent.Position = [3]float32{1, 0, 0}
+	child := NewTransform()
+	child.Position = [3]float32{0, 2, 0}
+	child.Scale = [3]float32{2, 2, 2}
+
+	pe := ecs.NewEntity(parent)
+	ce := ecs.NewEntity(child)
+	pe.AddChild(ce)
+	w.AddEntities(pe)
+	w.Update(0)
+
+	expect := mgl32.Translate3D(1, 2, 0).Mul4(mgl32.Scale3D(2, 2, 2))
+	if !mgl32.Mat4(child.World()).ApproxEqual(expect) {
+		t.Errorf("Update failed. Expected %v, but got %v", expect, child.World())
+	}
+
+	parent.Position = [3]float32{0, 0, 3}
+	w.Update(0)
+
+	expect = mgl32.Translate3D(0, 2, 3).Mul4(mgl32.Scale3D(2, 2, 2))
+	if !mgl32.Mat4(child.World()).ApproxEqual(expect) {
+		t.Errorf("Update failed. Expected %v, but got %v", expect, child.World())
+	}
+}
+
+func TestTransformSkipsClean(t *testing.T) {
+	tr := NewTransform()
+	tr.Position = [3]float32{1, 2, 3}
+	ident := mgl32.Ident4()
+
+	if !tr.update(ident) {
+		t.Errorf("update failed. Expected first update to compute the world matrix")
+	}
+	if tr.update(ident) {
+		t.Errorf("update failed. Expected unchanged transform to be skipped")
+	}
+	if !tr.update(mgl32.Translate3D(1, 0, 0)) {
+		t.Errorf("update failed. Expected changed parent to recompute the world matrix")
+	}
+
+	tr.SetEuler([3]float32{0, 90, 0})
+	if !tr.update(mgl32.Translate3D(1, 0, 0)) {
+		t.Errorf("update failed. Expected changed rotation to recompute the world matrix")
+	}
+}
+
+func TestTransformInterpolated(t *testing.T) {
+	tr := NewTransform()
+	tr.update(mgl32.Ident4())
+	tr.Position = [3]float32{2, 0, 0}
+	tr.update(mgl32.Ident4())
+
+	got := tr.Interpolated(0.5)
+	if got[12] != 1 {
+		t.Errorf("Interpolated failed. Expected X of 1, but got %v", got[12])
+	}
+	if got := tr.Interpolated(1); got[12] != 2 {
+		t.Errorf("Interpolated failed. Expected X of 2, but got %v", got[12])
+	}
+}
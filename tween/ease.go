@@ -0,0 +1,130 @@
+package tween
+
+import "math"
+
+// Ease maps the linear progress of a tween, from 0 to 1, to the progress of its value
+type Ease func(t float64) float64
+
+// Linear changes the value at a constant speed
+func Linear(t float64) float64 {
+	return t
+}
+
+// InQuad starts slow and accelerates
+func InQuad(t float64) float64 {
+	return t * t
+}
+
+// OutQuad starts fast and decelerates
+func OutQuad(t float64) float64 {
+	return t * (2 - t)
+}
+
+// InOutQuad accelerates until halfway and decelerates afterwards
+func InOutQuad(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// InCubic starts slow and accelerates, faster than [InQuad]
+func InCubic(t float64) float64 {
+	return t * t * t
+}
+
+// OutCubic starts fast and decelerates, faster than [OutQuad]
+func OutCubic(t float64) float64 {
+	t--
+	return t*t*t + 1
+}
+
+// InOutCubic accelerates until halfway and decelerates afterwards, faster than [InOutQuad]
+func InOutCubic(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return t*t*t/2 + 1
+}
+
+// InElastic winds up like a spring before moving towards the end value
+func InElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return -math.Pow(2, 10*t-10) * math.Sin((t*10-10.75)*(2*math.Pi)/3)
+}
+
+// OutElastic overshoots the end value and settles like a spring
+func OutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	return math.Pow(2, -10*t)*math.Sin((t*10-0.75)*(2*math.Pi)/3) + 1
+}
+
+// InOutElastic combines [InElastic] and [OutElastic]
+func InOutElastic(t float64) float64 {
+	if t == 0 || t == 1 {
+		return t
+	}
+	const c = (2 * math.Pi) / 4.5
+	if t < 0.5 {
+		return -(math.Pow(2, 20*t-10) * math.Sin((20*t-11.125)*c)) / 2
+	}
+	return math.Pow(2, -20*t+10)*math.Sin((20*t-11.125)*c)/2 + 1
+}
+
+// InBounce bounces a few times before moving towards the end value
+func InBounce(t float64) float64 {
+	return 1 - OutBounce(1-t)
+}
+
+// OutBounce bounces on the end value like a dropped ball
+func OutBounce(t float64) float64 {
+	const n, d = 7.5625, 2.75
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+
+// InOutBounce combines [InBounce] and [OutBounce]
+func InOutBounce(t float64) float64 {
+	if t < 0.5 {
+		return (1 - OutBounce(1-2*t)) / 2
+	}
+	return (1 + OutBounce(2*t-1)) / 2
+}
+
+const back = 1.70158
+
+// InBack moves slightly backwards before moving towards the end value
+func InBack(t float64) float64 {
+	return (back+1)*t*t*t - back*t*t
+}
+
+// OutBack overshoots the end value slightly before settling
+func OutBack(t float64) float64 {
+	t--
+	return 1 + (back+1)*t*t*t + back*t*t
+}
+
+// InOutBack combines [InBack] and [OutBack]
+func InOutBack(t float64) float64 {
+	const c = back * 1.525
+	if t < 0.5 {
+		return (math.Pow(2*t, 2) * ((c+1)*2*t - c)) / 2
+	}
+	return (math.Pow(2*t-2, 2)*((c+1)*(t*2-2)+c) + 2) / 2
+}
@@ -0,0 +1,94 @@
+package tween
+
+// Sequence plays animations one after another
+type Sequence struct {
+	animations []Animation
+	current    int
+	onComplete func()
+}
+
+// NewSequence creates a Sequence of the animations, which are played in the given order
+func NewSequence(animations ...Animation) *Sequence {
+	return &Sequence{animations: animations}
+}
+
+// OnComplete sets a function that is called once every animation of the Sequence is done
+func (s *Sequence) OnComplete(fn func()) *Sequence {
+	s.onComplete = fn
+	return s
+}
+
+// Update advances the current animation by dt seconds, time that remains once it is done is passed on to the next one
+func (s *Sequence) Update(dt float64) (float64, bool) {
+	if s.current >= len(s.animations) {
+		return dt, true
+	}
+
+	for s.current < len(s.animations) {
+		rest, done := s.animations[s.current].Update(dt)
+		if !done {
+			return 0, false
+		}
+		s.current++
+		dt = rest
+	}
+
+	if s.onComplete != nil {
+		s.onComplete()
+	}
+	return dt, true
+}
+
+// Group plays animations at the same time
+type Group struct {
+	animations []Animation
+	done       []bool
+	finished   bool
+	onComplete func()
+}
+
+// NewGroup creates a Group of the animations, which are played in parallel
+func NewGroup(animations ...Animation) *Group {
+	return &Group{animations: animations, done: make([]bool, len(animations))}
+}
+
+// OnComplete sets a function that is called once every animation of the Group is done
+func (g *Group) OnComplete(fn func()) *Group {
+	g.onComplete = fn
+	return g
+}
+
+// Update advances every animation that is not done yet by dt seconds.
+// The Group is done once its longest animation is done
+func (g *Group) Update(dt float64) (float64, bool) {
+	if g.finished {
+		return dt, true
+	}
+
+	rest := dt
+	for i, a := range g.animations {
+		if g.done[i] {
+			continue
+		}
+		r, done := a.Update(dt)
+		if !done {
+			continue
+		}
+		g.done[i] = true
+		if r < rest {
+			rest = r
+		}
+	}
+
+	for _, done := range g.done {
+		if !done {
+			return 0, false
+		}
+	}
+
+	g.finished = true
+	if g.onComplete != nil {
+		g.onComplete()
+	}
+	return rest, true
+}
@@ -0,0 +1,74 @@
+package tween
+
+import (
+	"sync"
+
+	"github.com/luukdegram/rebound/ecs"
+)
+
+// System is a [ecs.System] that plays animations every update, until they are done or stopped.
+// It runs before the systems in [ecs.StagePostUpdate], such as the [rebound.TransformSystem], so their changes are picked up in the same frame
+type System struct {
+	ecs.BaseSystem
+	m          sync.Mutex
+	animations []Animation
+}
+
+// NewSystem returns a new tween System
+func NewSystem() *System {
+	return &System{BaseSystem: ecs.NewBaseSystem()}
+}
+
+// Play starts playing the animation from the next update
+func (s *System) Play(a Animation) {
+	s.m.Lock()
+	s.animations = append(s.animations, a)
+	s.m.Unlock()
+}
+
+// Stop stops playing the animation, without calling its completion callback
+func (s *System) Stop(a Animation) {
+	s.m.Lock()
+	for i, playing := range s.animations {
+		if playing == a {
+			s.animations = append(s.animations[:i:i], s.animations[i+1:]...)
+			break
+		}
+	}
+	s.m.Unlock()
+}
+
+// Playing returns true if the animation is playing
+func (s *System) Playing(a Animation) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+	for _, playing := range s.animations {
+		if playing == a {
+			return true
+		}
+	}
+	return false
+}
+
+// Update advances every animation by dt seconds, removing the animations that are done
+func (s *System) Update(dt float64) {
+	s.m.Lock()
+	animations := s.animations
+	s.m.Unlock()
+
+	var done []Animation
+	for _, a := range animations {
+		if _, finished := a.Update(dt); finished {
+			done = append(done, a)
+		}
+	}
+
+	for _, a := range done {
+		s.Stop(a)
+	}
+}
+
+// Name returns the name of the tween System
+func (s *System) Name() string {
+	return "TweenSystem"
+}
@@ -0,0 +1,164 @@
+// Package tween animates values over time using easing curves, i.e. to move a camera or fade a light
+package tween
+
+import (
+	"time"
+
+	"github.com/go-gl/mathgl/mgl32"
+	"github.com/luukdegram/rebound"
+)
+
+// Forever makes a tween loop until it is stopped, see [Tween.Loop]
+const Forever = -1
+
+// Animation is anything that changes over time and can be played by the [System]
+type Animation interface {
+	// Update advances the animation by dt seconds.
+	// Once the animation is done it returns true, together with the part of dt that was not needed to finish it
+	Update(dt float64) (rest float64, done bool)
+}
+
+// Tween changes a single value from its value at the start of the tween to an end value
+type Tween struct {
+	duration   float64
+	elapsed    float64
+	ease       Ease
+	begin      func()
+	apply      func(t float64)
+	started    bool
+	done       bool
+	yoyo       bool
+	reverse    bool
+	loops      int
+	onComplete func()
+}
+
+// New creates a Tween that changes the target to the end value over the duration.
+// The value of the target when the tween starts is used as start value, lerp returns the value at progress t between both.
+// Use the typed constructors, such as [Vec3], for common types
+func New[T any](target *T, to T, d time.Duration, lerp func(from, to T, t float64) T) *Tween {
+	var from T
+	return &Tween{
+		duration: d.Seconds(),
+		ease:     Linear,
+		begin:    func() { from = *target },
+		apply:    func(t float64) { *target = lerp(from, to, t) },
+	}
+}
+
+// Float32 creates a Tween that changes the float to the end value
+func Float32(target *float32, to float32, d time.Duration) *Tween {
+	return New(target, to, d, func(from, to float32, t float64) float32 {
+		return from + (to-from)*float32(t)
+	})
+}
+
+// Vec3 creates a Tween that changes a vector, such as [rebound.Camera] Position or [rebound.Light] Colour, to the end value
+func Vec3(target *[3]float32, to [3]float32, d time.Duration) *Tween {
+	return New(target, to, d, func(from, to [3]float32, t float64) [3]float32 {
+		return mgl32.Vec3(from).Add(mgl32.Vec3(to).Sub(from).Mul(float32(t)))
+	})
+}
+
+// Vec4 creates a Tween that changes a vector with 4 elements to the end value
+func Vec4(target *[4]float32, to [4]float32, d time.Duration) *Tween {
+	return New(target, to, d, func(from, to [4]float32, t float64) [4]float32 {
+		return mgl32.Vec4(from).Add(mgl32.Vec4(to).Sub(from).Mul(float32(t)))
+	})
+}
+
+// Quat creates a Tween that rotates towards the end rotation, i.e. of a [rebound.Transform], taking the shortest path
+func Quat(target *mgl32.Quat, to mgl32.Quat, d time.Duration) *Tween {
+	return New(target, to, d, func(from, to mgl32.Quat, t float64) mgl32.Quat {
+		return rebound.Slerp(from, to, float32(t))
+	})
+}
+
+// Colour creates a Tween that changes a colour to the end colour
+func Colour(target *rebound.Colour, to rebound.Colour, d time.Duration) *Tween {
+	return New(target, to, d, func(from, to rebound.Colour, t float64) rebound.Colour {
+		f := float32(t)
+		return rebound.Colour{
+			R: from.R + (to.R-from.R)*f,
+			G: from.G + (to.G-from.G)*f,
+			B: from.B + (to.B-from.B)*f,
+			A: from.A + (to.A-from.A)*f,
+		}
+	})
+}
+
+// Ease sets the easing curve of the Tween, which is [Linear] by default
+func (tw *Tween) Ease(ease Ease) *Tween {
+	tw.ease = ease
+	return tw
+}
+
+// Loop plays the Tween n more times after it finishes, or until it is stopped when n is [Forever]
+func (tw *Tween) Loop(n int) *Tween {
+	tw.loops = n
+	return tw
+}
+
+// Yoyo makes every loop of the Tween play in the opposite direction of the previous one, see [Tween.Loop]
+func (tw *Tween) Yoyo() *Tween {
+	tw.yoyo = true
+	return tw
+}
+
+// OnComplete sets a function that is called once the Tween and all of its loops are done
+func (tw *Tween) OnComplete(fn func()) *Tween {
+	tw.onComplete = fn
+	return tw
+}
+
+// Update advances the Tween by dt seconds, see [Animation]
+func (tw *Tween) Update(dt float64) (float64, bool) {
+	if tw.done {
+		return dt, true
+	}
+	if !tw.started {
+		tw.started = true
+		tw.begin()
+	}
+
+	if tw.duration <= 0 {
+		tw.apply(tw.progress(1))
+		return dt, tw.finish()
+	}
+
+	tw.elapsed += dt
+	for tw.elapsed >= tw.duration {
+		rest := tw.elapsed - tw.duration
+		tw.apply(tw.progress(1))
+		if tw.loops == 0 {
+			return rest, tw.finish()
+		}
+		if tw.loops > 0 {
+			tw.loops--
+		}
+		if tw.yoyo {
+			tw.reverse = !tw.reverse
+		}
+		tw.elapsed = rest
+	}
+
+	tw.apply(tw.progress(tw.elapsed / tw.duration))
+	return 0, false
+}
+
+// progress returns the eased progress, taking the direction of a yoyo into account
+func (tw *Tween) progress(t float64) float64 {
+	if tw.reverse {
+		t = 1 - t
+	}
+	return tw.ease(t)
+}
+
+// finish marks the Tween as done and calls its completion callback
+func (tw *Tween) finish() bool {
+	tw.done = true
+	if tw.onComplete != nil {
+		tw.onComplete()
+	}
+	return true
+}
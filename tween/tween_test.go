@@ -0,0 +1,127 @@
+package tween
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/luukdegram/rebound"
+)
+
+func TestEaseEndpoints(t *testing.T) {
+	eases := map[string]Ease{
+		"Linear": Linear, "InQuad": InQuad, "OutQuad": OutQuad, "InOutQuad": InOutQuad,
+		"InCubic": InCubic, "OutCubic": OutCubic, "InOutCubic": InOutCubic,
+		"InElastic": InElastic, "OutElastic": OutElastic, "InOutElastic": InOutElastic,
+		"InBounce": InBounce, "OutBounce": OutBounce, "InOutBounce": InOutBounce,
+		"InBack": InBack, "OutBack": OutBack, "InOutBack": InOutBack,
+	}
+	for name, ease := range eases {
+		if v := ease(0); math.Abs(v) > 1e-9 {
+			t.Errorf("%s failed. Expected 0 at the start, but got %v", name, v)
+		}
+		if v := ease(1); math.Abs(v-1) > 1e-9 {
+			t.Errorf("%s failed. Expected 1 at the end, but got %v", name, v)
+		}
+	}
+}
+
+func TestTween(t *testing.T) {
+	camera := rebound.Camera{Position: [3]float32{0, 0, 0}}
+	completed := false
+	tw := Vec3(&camera.Position, [3]float32{4, 8, 0}, time.Second).OnComplete(func() { completed = true })
+
+	if _, done := tw.Update(0.25); done || camera.Position != [3]float32{1, 2, 0} {
+		t.Errorf("Tween failed. Expected %v, but got %v", [3]float32{1, 2, 0}, camera.Position)
+	}
+
+	rest, done := tw.Update(1)
+	if !done || !completed || rest != 0.25 || camera.Position != [3]float32{4, 8, 0} {
+		t.Errorf("Tween failed. Expected %v with 0.25 left, but got %v with %v left", [3]float32{4, 8, 0}, camera.Position, rest)
+	}
+}
+
+func TestYoyoLoop(t *testing.T) {
+	var v float32
+	tw := Float32(&v, 1, time.Second).Yoyo().Loop(1)
+
+	tw.Update(1.25)
+	if v != 0.75 {
+		t.Errorf("Yoyo failed. Expected %v, but got %v", 0.75, v)
+	}
+
+	if _, done := tw.Update(0.75); !done || v != 0 {
+		t.Errorf("Loop failed. Expected the tween to end at %v, but got %v", 0, v)
+	}
+}
+
+func TestLoopForever(t *testing.T) {
+	var v float32
+	tw := Float32(&v, 1, time.Second).Loop(Forever)
+	for i := 0; i < 10; i++ {
+		if _, done := tw.Update(1); done {
+			t.Fatalf("Loop failed. Expected the tween to keep looping")
+		}
+	}
+
+	tw.Update(0.5)
+	if v != 0.5 {
+		t.Errorf("Loop failed. Expected %v, but got %v", 0.5, v)
+	}
+}
+
+func TestSequence(t *testing.T) {
+	light := rebound.Light{Colour: [3]float32{0, 0, 0}}
+	completed := false
+	s := NewSequence(
+		Vec3(&light.Colour, [3]float32{1, 1, 1}, time.Second),
+		Vec3(&light.Colour, [3]float32{0, 1, 0}, time.Second),
+	).OnComplete(func() { completed = true })
+
+	s.Update(1.5)
+	if light.Colour != [3]float32{0.5, 1, 0.5} {
+		t.Errorf("Sequence failed. Expected %v, but got %v", [3]float32{0.5, 1, 0.5}, light.Colour)
+	}
+
+	if _, done := s.Update(0.5); !done || !completed || light.Colour != [3]float32{0, 1, 0} {
+		t.Errorf("Sequence failed. Expected %v, but got %v", [3]float32{0, 1, 0}, light.Colour)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	rc := rebound.RenderComponent{Scale: [3]float32{1, 1, 1}}
+	var v float32
+	g := NewGroup(
+		Vec3(&rc.Scale, [3]float32{2, 2, 2}, time.Second),
+		Float32(&v, 1, 2*time.Second),
+	)
+
+	if _, done := g.Update(1); done || rc.Scale != [3]float32{2, 2, 2} || v != 0.5 {
+		t.Errorf("Group failed. Expected both tweens to run, but got %v and %v", rc.Scale, v)
+	}
+
+	rest, done := g.Update(1.5)
+	if !done || rest != 0.5 || v != 1 {
+		t.Errorf("Group failed. Expected to finish with 0.5 left, but got %v", rest)
+	}
+}
+
+func TestSystem(t *testing.T) {
+	s := NewSystem()
+	var a, b float32
+	ta := Float32(&a, 1, time.Second)
+	tb := Float32(&b, 1, time.Second)
+	s.Play(ta)
+	s.Play(tb)
+	s.Stop(tb)
+
+	s.Update(0.5)
+	if a != 0.5 || b != 0 {
+		t.Errorf("System failed. Expected %v and %v, but got %v and %v", 0.5, 0, a, b)
+	}
+
+	s.Update(0.5)
+	if s.Playing(ta) {
+		t.Errorf("System failed. Expected the finished tween to be removed")
+	}
+}